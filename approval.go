@@ -0,0 +1,105 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// premiumRequired report whether GitLab answered a feature endpoint of a project or group
+// known to exist with 404, which is how editions without the feature respond
+func premiumRequired(resp *gitlab.Response) bool {
+	if resp == nil || resp.Response == nil {
+		return false
+	}
+	return resp.StatusCode == http.StatusNotFound
+}
+
+// ListApprovalRules list the project level merge request approval rules
+func (git *gitlabServer) ListApprovalRules() ([]map[string]interface{}, error) {
+	return git.ListApprovalRulesWithContext(context.Background())
+}
+
+// ListApprovalRulesWithContext is like ListApprovalRules but binds the request to ctx
+func (git *gitlabServer) ListApprovalRulesWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := git.listApprovalRules(ctx, int(projectId))
+	if err != nil {
+		return nil, err
+	}
+	return convertToMaps(rules)
+}
+
+// SetApprovalRule create the approval rule, or update the existing rule with the same name,
+// requiring approvalsRequired approvals from userIds, it is a premium feature
+func (git *gitlabServer) SetApprovalRule(name string, approvalsRequired int, userIds []int) (string, error) {
+	return git.SetApprovalRuleWithContext(context.Background(), name, approvalsRequired, userIds)
+}
+
+// SetApprovalRuleWithContext is like SetApprovalRule but binds the request to ctx
+func (git *gitlabServer) SetApprovalRuleWithContext(ctx context.Context, name string, approvalsRequired int, userIds []int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	rules, err := git.listApprovalRules(ctx, int(projectId))
+	if err != nil {
+		return fmt.Sprintf("set approval rule: <%s> error", name), err
+	}
+	for _, rule := range rules {
+		if rule.Name != name {
+			continue
+		}
+		options := &gitlab.UpdateProjectLevelRuleOptions{
+			Name:              &name,
+			ApprovalsRequired: &approvalsRequired,
+			UserIDs:           &userIds,
+		}
+		_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			_, resp, err = git.api.Projects.UpdateProjectApprovalRule(int(projectId), rule.ID, options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return fmt.Sprintf("update approval rule: <%s> error", name), err
+		}
+		return fmt.Sprintf("update approval rule: <%s> ok", name), nil
+	}
+	options := &gitlab.CreateProjectLevelRuleOptions{
+		Name:              &name,
+		ApprovalsRequired: &approvalsRequired,
+		UserIDs:           &userIds,
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Projects.CreateProjectApprovalRule(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("create approval rule: <%s> error", name), err
+	}
+	return fmt.Sprintf("create approval rule: <%s> ok", name), nil
+}
+
+// listApprovalRules list the approval rules of the project
+func (git *gitlabServer) listApprovalRules(ctx context.Context, projectId int) ([]*gitlab.ProjectApprovalRule, error) {
+	var rules []*gitlab.ProjectApprovalRule
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		rules, resp, err = git.api.Projects.GetProjectApprovalRules(projectId, optionFuncs...)
+		return
+	})
+	if err != nil {
+		// the project id is already resolved, so a 404 is the endpoint missing from the edition
+		if premiumRequired(resp) {
+			return nil, fmt.Errorf("approval rules: %v: %w", respStatus(resp, err), ErrPremiumRequired)
+		}
+		if resp != nil && resp.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("approval rules of project id %d: %w", projectId, ErrPermissionDenied)
+		}
+		return nil, err
+	}
+	return rules, nil
+}
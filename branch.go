@@ -0,0 +1,248 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListBranches list all branches of the project
+func (git *gitlabServer) ListBranches() ([]map[string]interface{}, error) {
+	return git.ListBranchesWithContext(context.Background())
+}
+
+// ListBranchesWithContext is like ListBranches but binds the request to ctx
+func (git *gitlabServer) ListBranchesWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListBranchesOptions{
+		ListOptions: git.listOptions(),
+	}
+	var branchSlice []*gitlab.Branch
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list branches: exceeded max pages %d", git.maxPages())
+		}
+		var branches []*gitlab.Branch
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			branches, resp, err = git.api.Branches.ListBranches(int(projectId), options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		branchSlice = append(branchSlice, branches...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(branchSlice)
+}
+
+// CreateBranch create a new branch from ref
+func (git *gitlabServer) CreateBranch(branchName, ref string) (string, error) {
+	return git.CreateBranchWithContext(context.Background(), branchName, ref)
+}
+
+// CreateBranchWithContext is like CreateBranch but binds the request to ctx
+func (git *gitlabServer) CreateBranchWithContext(ctx context.Context, branchName, ref string) (string, error) {
+	if err := requireArgs("create branch", "branchName", branchName, "ref", ref); err != nil {
+		return fmt.Sprintf("create branch: <%s> error", branchName), err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Branches.GetBranch(int(projectId), branchName, optionFuncs...)
+		return
+	})
+	if err == nil {
+		return fmt.Sprintf("create branch: <%s> error", branchName), fmt.Errorf("branch %s: %w", branchName, ErrBranchExists)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return fmt.Sprintf("create branch: <%s> error", branchName), err
+	}
+	options := &gitlab.CreateBranchOptions{
+		Branch: &branchName,
+		Ref:    &ref,
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Branches.CreateBranch(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("create branch: <%s> error", branchName), err
+	}
+	return fmt.Sprintf("create branch: <%s> from <%s> ok", branchName, ref), nil
+}
+
+// DeleteBranch delete a branch, protected and default branches are refused with ErrBranchProtected
+// unless force is set, force unprotects the branch first, GitLab still refuses to delete the
+// default branch so switch it with SetDefaultBranch beforehand
+func (git *gitlabServer) DeleteBranch(branch string, force bool) (string, error) {
+	return git.DeleteBranchWithContext(context.Background(), branch, force)
+}
+
+// DeleteBranchWithContext is like DeleteBranch but binds the request to ctx
+func (git *gitlabServer) DeleteBranchWithContext(ctx context.Context, branch string, force bool) (string, error) {
+	if err := requireArgs("delete branch", "branch", branch); err != nil {
+		return fmt.Sprintf("delete branch: <%s> error", branch), err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	var b *gitlab.Branch
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		b, resp, err = git.api.Branches.GetBranch(int(projectId), branch, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Sprintf("delete branch: <%s> error", branch), fmt.Errorf("branch %s: %w", branch, ErrBranchNotFound)
+		}
+		return fmt.Sprintf("delete branch: <%s> error", branch), err
+	}
+	if (b.Protected || b.Default) && !force {
+		return fmt.Sprintf("delete branch: <%s> error", branch), fmt.Errorf("branch %s: %w", branch, ErrBranchProtected)
+	}
+	if git.DryRun {
+		git.logger().Debugf("dry run: delete branch %s", branch)
+		return fmt.Sprintf("delete branch: <%s> ok, dry run", branch), nil
+	}
+	if b.Protected {
+		if msg, err := git.UnprotectBranchWithContext(ctx, branch); err != nil {
+			return msg, err
+		}
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.Branches.DeleteBranch(int(projectId), branch, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("delete branch: <%s> error", branch), err
+	}
+	return fmt.Sprintf("delete branch: <%s> ok", branch), nil
+}
+
+// SetDefaultBranch set the project's default branch, the branch must already exist
+func (git *gitlabServer) SetDefaultBranch(branch string) (string, error) {
+	return git.SetDefaultBranchWithContext(context.Background(), branch)
+}
+
+// SetDefaultBranchWithContext is like SetDefaultBranch but binds the request to ctx
+func (git *gitlabServer) SetDefaultBranchWithContext(ctx context.Context, branch string) (string, error) {
+	if err := requireArgs("set default branch", "branch", branch); err != nil {
+		return fmt.Sprintf("set default branch: <%s> error", branch), err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Branches.GetBranch(int(projectId), branch, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Sprintf("set default branch: <%s> error", branch), fmt.Errorf("branch %s: %w", branch, ErrBranchNotFound)
+		}
+		return fmt.Sprintf("set default branch: <%s> error", branch), err
+	}
+	options := &gitlab.EditProjectOptions{DefaultBranch: &branch}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Projects.EditProject(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("set default branch: <%s> error", branch), err
+	}
+	return fmt.Sprintf("set default branch: <%s> ok", branch), nil
+}
+
+// ListProtectedBranches list all protected branches of the project
+func (git *gitlabServer) ListProtectedBranches() ([]map[string]interface{}, error) {
+	return git.ListProtectedBranchesWithContext(context.Background())
+}
+
+// ListProtectedBranchesWithContext is like ListProtectedBranches but binds the request to ctx
+func (git *gitlabServer) ListProtectedBranchesWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := gitlab.ListProtectedBranchesOptions(git.listOptions())
+	var branchSlice []*gitlab.ProtectedBranch
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list protected branches: exceeded max pages %d", git.maxPages())
+		}
+		var branches []*gitlab.ProtectedBranch
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			branches, resp, err = git.api.ProtectedBranches.ListProtectedBranches(int(projectId), &options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		branchSlice = append(branchSlice, branches...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(branchSlice)
+}
+
+// ProtectBranch protect a branch with the given push and merge access levels
+func (git *gitlabServer) ProtectBranch(branch string, pushLevel, mergeLevel gitlab.AccessLevelValue) (string, error) {
+	return git.ProtectBranchWithContext(context.Background(), branch, pushLevel, mergeLevel)
+}
+
+// ProtectBranchWithContext is like ProtectBranch but binds the request to ctx
+func (git *gitlabServer) ProtectBranchWithContext(ctx context.Context, branch string, pushLevel, mergeLevel gitlab.AccessLevelValue) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.ProtectRepositoryBranchesOptions{
+		Name:             &branch,
+		PushAccessLevel:  gitlab.AccessLevel(pushLevel),
+		MergeAccessLevel: gitlab.AccessLevel(mergeLevel),
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.ProtectedBranches.ProtectRepositoryBranches(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("protect branch: <%s> error", branch), err
+	}
+	return fmt.Sprintf("protect branch: <%s> ok", branch), nil
+}
+
+// UnprotectBranch remove the protection of a branch
+func (git *gitlabServer) UnprotectBranch(branch string) (string, error) {
+	return git.UnprotectBranchWithContext(context.Background(), branch)
+}
+
+// UnprotectBranchWithContext is like UnprotectBranch but binds the request to ctx
+func (git *gitlabServer) UnprotectBranchWithContext(ctx context.Context, branch string) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.ProtectedBranches.UnprotectRepositoryBranches(int(projectId), branch, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("unprotect branch: <%s> error", branch), err
+	}
+	return fmt.Sprintf("unprotect branch: <%s> ok", branch), nil
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// FileResult is the outcome of one file written by CreateFilesConcurrent
+type FileResult struct {
+	Message string
+	Err     error
+}
+
+// CreateFilesConcurrent create each file in its own commit with at most concurrency uploads in
+// flight, every file gets a result and the returned error summarizes the failed ones,
+// CommitMultipleFiles is cheaper when a single commit is acceptable
+func (git *gitlabServer) CreateFilesConcurrent(branch string, files map[string]string, commitMessage string, concurrency int) (map[string]FileResult, error) {
+	return git.CreateFilesConcurrentWithContext(context.Background(), branch, files, commitMessage, concurrency)
+}
+
+// CreateFilesConcurrentWithContext is like CreateFilesConcurrent but binds the requests to ctx
+func (git *gitlabServer) CreateFilesConcurrentWithContext(ctx context.Context, branch string, files map[string]string, commitMessage string, concurrency int) (map[string]FileResult, error) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]FileResult, len(files))
+		jobs    = make(chan string)
+	)
+	for i := 0; i < concurrency && i < len(paths); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				msg, err := git.CreateFileWithContext(ctx, branch, path, files[path], commitMessage)
+				mu.Lock()
+				results[path] = FileResult{Message: msg, Err: err}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, path := range paths {
+		jobs <- path
+	}
+	close(jobs)
+	wg.Wait()
+
+	var failed []string
+	for _, path := range paths {
+		if results[path].Err != nil {
+			failed = append(failed, path)
+		}
+	}
+	if len(failed) > 0 {
+		first := failed[0]
+		return results, fmt.Errorf("create files: %d of %d failed, %s: %w", len(failed), len(paths), first, results[first].Err)
+	}
+	return results, nil
+}
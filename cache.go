@@ -0,0 +1,98 @@
+package git
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultProjectIdCacheTTL = 5 * time.Minute
+
+type projectIdKey struct {
+	groupId     int
+	groupName   string
+	projectName string
+	match       ProjectMatch
+	ignoreCase  bool
+}
+
+type projectIdEntry struct {
+	id      float64
+	path    string
+	expires time.Time
+}
+
+// projectIdCache cache resolved project ids and paths per group, ProjectName and match mode,
+// it is safe for concurrent use
+type projectIdCache struct {
+	mu      sync.RWMutex
+	entries map[projectIdKey]projectIdEntry
+}
+
+func newProjectIdCache() *projectIdCache {
+	return &projectIdCache{entries: make(map[projectIdKey]projectIdEntry)}
+}
+
+func (c *projectIdCache) get(key projectIdKey) (projectIdEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return projectIdEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *projectIdCache) set(key projectIdKey, id float64, path string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = projectIdEntry{id: id, path: path, expires: time.Now().Add(ttl)}
+}
+
+// removeId drop every entry resolving to id
+func (c *projectIdCache) removeId(id float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.id == id {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *projectIdCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[projectIdKey]projectIdEntry)
+}
+
+// projectIdKey return the cache key of the configured project
+func (git *gitlabServer) projectIdKey() projectIdKey {
+	key := projectIdKey{
+		groupName:   git.GroupName,
+		projectName: git.ProjectName,
+		match:       git.ProjectMatch,
+		ignoreCase:  git.ProjectMatchIgnoreCase,
+	}
+	if git.GroupId != nil {
+		key.groupId = *git.GroupId
+	}
+	return key
+}
+
+// projectIdCacheTTL return how long a resolved project id is cached, zero means caching is off
+func (git *gitlabServer) projectIdCacheTTL() time.Duration {
+	if git.projectIds == nil || git.ProjectIdCacheTTL < 0 {
+		return 0
+	}
+	if git.ProjectIdCacheTTL == 0 {
+		return defaultProjectIdCacheTTL
+	}
+	return git.ProjectIdCacheTTL
+}
+
+// InvalidateProjectIdCache drop all cached project ids
+func (git *gitlabServer) InvalidateProjectIdCache() {
+	if git.projectIds != nil {
+		git.projectIds.clear()
+	}
+}
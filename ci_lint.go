@@ -0,0 +1,57 @@
+package git
+
+import (
+	"context"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ValidateCIConfig lint .gitlab-ci.yml content with the instance wide CI lint and return
+// whether it is valid along with the error messages
+func (git *gitlabServer) ValidateCIConfig(content string) (bool, []string, error) {
+	return git.ValidateCIConfigWithContext(context.Background(), content)
+}
+
+// ValidateCIConfigWithContext is like ValidateCIConfig but binds the request to ctx
+func (git *gitlabServer) ValidateCIConfigWithContext(ctx context.Context, content string) (bool, []string, error) {
+	if err := requireArgs("validate ci config", "content", content); err != nil {
+		return false, nil, err
+	}
+	var result *gitlab.LintResult
+	_, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		result, resp, err = git.api.Validate.Lint(&gitlab.LintOptions{Content: content}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	return result.Status == "valid", result.Errors, nil
+}
+
+// ValidateProjectCIConfig lint .gitlab-ci.yml content in the context of the project, so
+// local includes and project variables resolve, and return whether it is valid along with
+// the error messages
+func (git *gitlabServer) ValidateProjectCIConfig(content string) (bool, []string, error) {
+	return git.ValidateProjectCIConfigWithContext(context.Background(), content)
+}
+
+// ValidateProjectCIConfigWithContext is like ValidateProjectCIConfig but binds the request to ctx
+func (git *gitlabServer) ValidateProjectCIConfigWithContext(ctx context.Context, content string) (bool, []string, error) {
+	if err := requireArgs("validate project ci config", "content", content); err != nil {
+		return false, nil, err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	options := &gitlab.ProjectNamespaceLintOptions{Content: &content}
+	var result *gitlab.ProjectLintResult
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		result, resp, err = git.api.Validate.ProjectNamespaceLint(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	return result.Valid, result.Errors, nil
+}
@@ -0,0 +1,290 @@
+package git
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// GitClient is the set of operations provided by the gitlab server, depend on it
+// instead of *gitlabServer so a fake can be injected in tests
+type GitClient interface {
+	WithProject(projectName string) GitClient
+	WithGroup(groupId int, groupName string) GitClient
+	WithAuthor(name, email string) GitClient
+	CreateProject(opts ...ProjectOption) (int, string, error)
+	CreateProjectWithContext(ctx context.Context, opts ...ProjectOption) (int, string, error)
+	DeleteProject() (bool, string, error)
+	DeleteProjectWithContext(ctx context.Context) (bool, string, error)
+	DeleteProjectById(id int) (bool, string, error)
+	DeleteProjectByIdWithContext(ctx context.Context, id int) (bool, string, error)
+	ForkProject(namespaceId int, newName, newPath string) (int, error)
+	ForkProjectWithContext(ctx context.Context, namespaceId int, newName, newPath string) (int, error)
+	TransferProject(targetNamespaceId int) (string, error)
+	TransferProjectWithContext(ctx context.Context, targetNamespaceId int) (string, error)
+	EditProject(opts *gitlab.EditProjectOptions) (string, error)
+	EditProjectWithContext(ctx context.Context, opts *gitlab.EditProjectOptions) (string, error)
+	ListProjectHook() (data []map[string]interface{}, err error)
+	ListProjectHookWithContext(ctx context.Context) (data []map[string]interface{}, err error)
+	FindProjectHookByURL(url string) (*gitlab.ProjectHook, error)
+	FindProjectHookByURLWithContext(ctx context.Context, url string) (*gitlab.ProjectHook, error)
+	IsProjectHookExists(url string) (string, error)
+	IsProjectHookExistsWithContext(ctx context.Context, url string) (string, error)
+	ProjectHookExists(url string) (bool, error)
+	ProjectHookExistsWithContext(ctx context.Context, url string) (bool, error)
+	CreateProjectHook(opts *gitlab.AddProjectHookOptions) (int, error)
+	CreateProjectHookWithContext(ctx context.Context, opts *gitlab.AddProjectHookOptions) (int, error)
+	CreateProjectHookByPush(url, branch string, pushEvents, enableSSLVerification bool) (string, error)
+	CreateProjectHookByPushWithContext(ctx context.Context, url, branch string, pushEvents, enableSSLVerification bool) (string, error)
+	CreateProjectHookByTag(url, branch string, tagPushEvents, enableSSLVerification bool) (string, error)
+	CreateProjectHookByTagWithContext(ctx context.Context, url, branch string, tagPushEvents, enableSSLVerification bool) (string, error)
+	CreateProjectHookByMergeRequest(url string, mergeRequestsEvents, enableSSLVerification bool) (string, error)
+	CreateProjectHookByMergeRequestWithContext(ctx context.Context, url string, mergeRequestsEvents, enableSSLVerification bool) (string, error)
+	CreateProjectHookByPipeline(url string, pipelineEvents, enableSSLVerification bool) (string, error)
+	CreateProjectHookByPipelineWithContext(ctx context.Context, url string, pipelineEvents, enableSSLVerification bool) (string, error)
+	GetProjectHookId(url string) (int, error)
+	GetProjectHookIdWithContext(ctx context.Context, url string) (int, error)
+	UpdateProjectHook(hookId int, opts *gitlab.EditProjectHookOptions) (string, error)
+	UpdateProjectHookWithContext(ctx context.Context, hookId int, opts *gitlab.EditProjectHookOptions) (string, error)
+	UpdateProjectHookByURL(url string, opts *gitlab.EditProjectHookOptions) (string, error)
+	UpdateProjectHookByURLWithContext(ctx context.Context, url string, opts *gitlab.EditProjectHookOptions) (string, error)
+	DeleteProjectHook(hookId int) (string, error)
+	DeleteProjectHookWithContext(ctx context.Context, hookId int) (string, error)
+	ListProject() ([]map[string]interface{}, error)
+	ListProjectWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	SearchProjects(query string) ([]map[string]interface{}, error)
+	SearchProjectsWithContext(ctx context.Context, query string) ([]map[string]interface{}, error)
+	GetProject() (map[string]interface{}, error)
+	GetProjectWithContext(ctx context.Context) (map[string]interface{}, error)
+	GetProjectById(projectId int) (map[string]interface{}, error)
+	GetProjectByIdWithContext(ctx context.Context, projectId int) (map[string]interface{}, error)
+	GetProjectByPath(pathWithNamespace string) (map[string]interface{}, error)
+	GetProjectByPathWithContext(ctx context.Context, pathWithNamespace string) (map[string]interface{}, error)
+	GetProjectStatistics() (map[string]interface{}, error)
+	GetProjectStatisticsWithContext(ctx context.Context) (map[string]interface{}, error)
+	GetDefaultBranch() (string, error)
+	GetDefaultBranchWithContext(ctx context.Context) (string, error)
+	GetProjectId() (float64, error)
+	GetProjectIdWithContext(ctx context.Context) (float64, error)
+	IsProjectExists() (string, error)
+	IsProjectExistsWithContext(ctx context.Context) (string, error)
+	ProjectExists() (bool, error)
+	ProjectExistsWithContext(ctx context.Context) (bool, error)
+	ListProjectCommit(branch string, opts ...CommitOption) (data []map[string]interface{}, err error)
+	ListProjectCommitWithContext(ctx context.Context, branch string, opts ...CommitOption) (data []map[string]interface{}, err error)
+	ListProjectCommitFormat(branch string, opts ...CommitOption) (data []map[string]interface{}, err error)
+	ListProjectCommitFormatWithContext(ctx context.Context, branch string, opts ...CommitOption) (data []map[string]interface{}, err error)
+	RollbackProjectCommit(branch, commitId string) (string, error)
+	RollbackProjectCommitWithContext(ctx context.Context, branch, commitId string) (string, error)
+	CreateFile(branch, filename, fileContent, commitMessage string) (string, error)
+	CreateFileWithContext(ctx context.Context, branch, filename, fileContent, commitMessage string) (string, error)
+	CreateFileInter(branch, filename string, f fileContentInter, commitMessage string) (string, error)
+	CreateFileInterWithContext(ctx context.Context, branch, filename string, f fileContentInter, commitMessage string) (string, error)
+	CreateFileInterJSON(branch, filename string, f jsonFileContentInter, commitMessage string) (string, error)
+	CreateFileInterJSONWithContext(ctx context.Context, branch, filename string, f jsonFileContentInter, commitMessage string) (string, error)
+	UpdateFileInter(branch, filename string, f fileContentInter, commitMessage string) (string, error)
+	UpdateFileInterWithContext(ctx context.Context, branch, filename string, f fileContentInter, commitMessage string) (string, error)
+	UpdateFile(branch, filename, fileContent, commitMessage string) (string, error)
+	UpdateFileWithContext(ctx context.Context, branch, filename, fileContent, commitMessage string) (string, error)
+	CreateOrUpdateFile(branch, filename, fileContent, commitMessage string) (string, error)
+	CreateOrUpdateFileWithContext(ctx context.Context, branch, filename, fileContent, commitMessage string) (string, error)
+	CreateFileIfAbsent(branch, filename, fileContent, commitMessage string) (bool, error)
+	CreateFileIfAbsentWithContext(ctx context.Context, branch, filename, fileContent, commitMessage string) (bool, error)
+	DeleteFile(branch, filename, commitMessage string) (string, error)
+	DeleteFileWithContext(ctx context.Context, branch, filename, commitMessage string) (string, error)
+	GetRawFile(branch, filename string) (string, error)
+	GetRawFileWithContext(ctx context.Context, branch, filename string) (string, error)
+	GetRawFileAtRef(ref, filename string) (string, error)
+	GetRawFileAtRefWithContext(ctx context.Context, ref, filename string) (string, error)
+	GetRawFileBytes(ref, filename string) ([]byte, error)
+	GetRawFileBytesWithContext(ctx context.Context, ref, filename string) ([]byte, error)
+	IsFileExists(branch, filename string) bool
+	IsFileExistsWithContext(ctx context.Context, branch, filename string) bool
+	FileExists(branch, filename string) (bool, error)
+	FileExistsWithContext(ctx context.Context, branch, filename string) (bool, error)
+	GetFileMetadata(branch, filename string) (map[string]interface{}, error)
+	GetFileMetadataWithContext(ctx context.Context, branch, filename string) (map[string]interface{}, error)
+	GetFileDecoded(branch, filename string) (string, map[string]interface{}, error)
+	GetFileDecodedWithContext(ctx context.Context, branch, filename string) (string, map[string]interface{}, error)
+	CreateTag(branch, tagName, message string) error
+	CreateTagWithContext(ctx context.Context, branch, tagName, message string) error
+	ListBranches() ([]map[string]interface{}, error)
+	ListBranchesWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	CreateBranch(branchName, ref string) (string, error)
+	CreateBranchWithContext(ctx context.Context, branchName, ref string) (string, error)
+	DeleteBranch(branch string, force bool) (string, error)
+	DeleteBranchWithContext(ctx context.Context, branch string, force bool) (string, error)
+	SetDefaultBranch(branch string) (string, error)
+	SetDefaultBranchWithContext(ctx context.Context, branch string) (string, error)
+	ListProtectedBranches() ([]map[string]interface{}, error)
+	ListProtectedBranchesWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	ProtectBranch(branch string, pushLevel, mergeLevel gitlab.AccessLevelValue) (string, error)
+	ProtectBranchWithContext(ctx context.Context, branch string, pushLevel, mergeLevel gitlab.AccessLevelValue) (string, error)
+	UnprotectBranch(branch string) (string, error)
+	UnprotectBranchWithContext(ctx context.Context, branch string) (string, error)
+	ListTags() ([]map[string]interface{}, error)
+	ListTagsWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	GetTag(tagName string) (map[string]interface{}, error)
+	GetTagWithContext(ctx context.Context, tagName string) (map[string]interface{}, error)
+	DeleteTag(tagName string) (string, error)
+	DeleteTagWithContext(ctx context.Context, tagName string) (string, error)
+	CreateTagIfAbsent(ref, tagName, message string) (bool, error)
+	CreateTagIfAbsentWithContext(ctx context.Context, ref, tagName, message string) (bool, error)
+	ListProtectedTags() ([]map[string]interface{}, error)
+	ListProtectedTagsWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	ProtectTag(tagPattern string, createLevel gitlab.AccessLevelValue) (string, error)
+	ProtectTagWithContext(ctx context.Context, tagPattern string, createLevel gitlab.AccessLevelValue) (string, error)
+	CommitMultipleFiles(branch, commitMessage string, actions []CommitAction) (string, error)
+	CommitMultipleFilesWithContext(ctx context.Context, branch, commitMessage string, actions []CommitAction) (string, error)
+	CreateFilesInter(branch string, f multiFileContentInter, commitMessage string) (string, error)
+	CreateFilesInterWithContext(ctx context.Context, branch string, f multiFileContentInter, commitMessage string) (string, error)
+	CompareRefs(from, to string, straight bool) (map[string]interface{}, error)
+	CompareRefsWithContext(ctx context.Context, from, to string, straight bool) (map[string]interface{}, error)
+	GetCommit(sha string) (map[string]interface{}, error)
+	GetCommitWithContext(ctx context.Context, sha string) (map[string]interface{}, error)
+	GetCommitDiff(sha string) ([]map[string]interface{}, error)
+	GetCommitDiffWithContext(ctx context.Context, sha string) ([]map[string]interface{}, error)
+	GetLatestCommit(branch string) (map[string]interface{}, error)
+	GetLatestCommitWithContext(ctx context.Context, branch string) (map[string]interface{}, error)
+	GetCommitSignature(sha string) (map[string]interface{}, error)
+	GetCommitSignatureWithContext(ctx context.Context, sha string) (map[string]interface{}, error)
+	CherryPickCommit(sha, targetBranch string) (string, error)
+	CherryPickCommitWithContext(ctx context.Context, sha, targetBranch string) (string, error)
+	MoveFile(branch, oldPath, newPath, commitMessage string) (string, error)
+	MoveFileWithContext(ctx context.Context, branch, oldPath, newPath, commitMessage string) (string, error)
+	ListMergeRequests(state, targetBranch string, labels ...string) ([]map[string]interface{}, error)
+	ListMergeRequestsWithContext(ctx context.Context, state, targetBranch string, labels ...string) ([]map[string]interface{}, error)
+	CreateMergeRequest(sourceBranch, targetBranch, title, description string) (int, error)
+	CreateMergeRequestWithContext(ctx context.Context, sourceBranch, targetBranch, title, description string) (int, error)
+	MergeMergeRequest(mrIID int) (string, error)
+	MergeMergeRequestWithContext(ctx context.Context, mrIID int) (string, error)
+	CloseMergeRequest(mrIID int) (string, error)
+	CloseMergeRequestWithContext(ctx context.Context, mrIID int) (string, error)
+	ReopenMergeRequest(mrIID int) (string, error)
+	ReopenMergeRequestWithContext(ctx context.Context, mrIID int) (string, error)
+	ApproveMergeRequest(mrIID int) (string, error)
+	ApproveMergeRequestWithContext(ctx context.Context, mrIID int) (string, error)
+	UnapproveMergeRequest(mrIID int) (string, error)
+	UnapproveMergeRequestWithContext(ctx context.Context, mrIID int) (string, error)
+	WaitForMergeable(mrIID int, timeout time.Duration) error
+	WaitForMergeableWithContext(ctx context.Context, mrIID int, timeout time.Duration) error
+	PromoteBranch(source, target string, deleteSource bool, timeout time.Duration) (string, error)
+	PromoteBranchWithContext(ctx context.Context, source, target string, deleteSource bool, timeout time.Duration) (string, error)
+	ListApprovalRules() ([]map[string]interface{}, error)
+	ListApprovalRulesWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	SetApprovalRule(name string, approvalsRequired int, userIds []int) (string, error)
+	SetApprovalRuleWithContext(ctx context.Context, name string, approvalsRequired int, userIds []int) (string, error)
+	CreateFilesConcurrent(branch string, files map[string]string, commitMessage string, concurrency int) (map[string]FileResult, error)
+	CreateFilesConcurrentWithContext(ctx context.Context, branch string, files map[string]string, commitMessage string, concurrency int) (map[string]FileResult, error)
+	ValidateCIConfig(content string) (bool, []string, error)
+	ValidateCIConfigWithContext(ctx context.Context, content string) (bool, []string, error)
+	ValidateProjectCIConfig(content string) (bool, []string, error)
+	ValidateProjectCIConfigWithContext(ctx context.Context, content string) (bool, []string, error)
+	ListDeployKeys() ([]map[string]interface{}, error)
+	ListDeployKeysWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	AddDeployKey(title, key string, canPush bool) (int, error)
+	AddDeployKeyWithContext(ctx context.Context, title, key string, canPush bool) (int, error)
+	EnableDeployKey(keyId int) (string, error)
+	EnableDeployKeyWithContext(ctx context.Context, keyId int) (string, error)
+	ListEnvironments() ([]map[string]interface{}, error)
+	ListEnvironmentsWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	CreateEnvironment(name, externalURL string) (int, error)
+	CreateEnvironmentWithContext(ctx context.Context, name, externalURL string) (int, error)
+	StopEnvironment(envId int) (string, error)
+	StopEnvironmentWithContext(ctx context.Context, envId int) (string, error)
+	ResolveGroup() (GitClient, error)
+	ResolveGroupWithContext(ctx context.Context) (GitClient, error)
+	ResolveGroupId(groupPath string) (int, error)
+	ResolveGroupIdWithContext(ctx context.Context, groupPath string) (int, error)
+	CreateGroup(name, path string, parentId *int) (int, error)
+	CreateGroupWithContext(ctx context.Context, name, path string, parentId *int) (int, error)
+	ListGroupHooks() ([]map[string]interface{}, error)
+	ListGroupHooksWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	CreateGroupHook(opts *gitlab.AddGroupHookOptions) (int, error)
+	CreateGroupHookWithContext(ctx context.Context, opts *gitlab.AddGroupHookOptions) (int, error)
+	Ping() error
+	PingWithContext(ctx context.Context) error
+	Version() (string, error)
+	VersionWithContext(ctx context.Context) (string, error)
+	CurrentUser() (map[string]interface{}, error)
+	CurrentUserWithContext(ctx context.Context) (map[string]interface{}, error)
+	GetImportStatus(projectId int) (string, error)
+	GetImportStatusWithContext(ctx context.Context, projectId int) (string, error)
+	WaitForProjectReady(projectId int, timeout time.Duration) error
+	WaitForProjectReadyWithContext(ctx context.Context, projectId int, timeout time.Duration) error
+	ListIssues(state string) ([]map[string]interface{}, error)
+	ListIssuesWithContext(ctx context.Context, state string) ([]map[string]interface{}, error)
+	CreateIssue(title, description string, labels []string, opts ...IssueOption) (int, error)
+	CreateIssueWithContext(ctx context.Context, title, description string, labels []string, opts ...IssueOption) (int, error)
+	ListJobs(pipelineId int) ([]map[string]interface{}, error)
+	ListJobsWithContext(ctx context.Context, pipelineId int) ([]map[string]interface{}, error)
+	DownloadArtifacts(jobId int) (io.ReadCloser, error)
+	DownloadArtifactsWithContext(ctx context.Context, jobId int) (io.ReadCloser, error)
+	ListLabels() ([]map[string]interface{}, error)
+	ListLabelsWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	CreateLabel(name, color, description string) (string, error)
+	CreateLabelWithContext(ctx context.Context, name, color, description string) (string, error)
+	DeleteLabel(name string) (string, error)
+	DeleteLabelWithContext(ctx context.Context, name string) (string, error)
+	ListProjectMembers() ([]map[string]interface{}, error)
+	ListProjectMembersWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	AddProjectMember(userId int, accessLevel gitlab.AccessLevelValue) (string, error)
+	AddProjectMemberWithContext(ctx context.Context, userId int, accessLevel gitlab.AccessLevelValue) (string, error)
+	RemoveProjectMember(userId int) (string, error)
+	RemoveProjectMemberWithContext(ctx context.Context, userId int) (string, error)
+	ListGroupMembers() ([]map[string]interface{}, error)
+	ListGroupMembersWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	CreateCommitComment(sha, note string) (int, error)
+	CreateCommitCommentWithContext(ctx context.Context, sha, note string) (int, error)
+	CreateMergeRequestNote(mrIID int, note string) (int, error)
+	CreateMergeRequestNoteWithContext(ctx context.Context, mrIID int, note string) (int, error)
+	ListProjectPage(page int, filter ProjectFilter) ([]map[string]interface{}, PageInfo, error)
+	ListProjectPageWithContext(ctx context.Context, page int, filter ProjectFilter) ([]map[string]interface{}, PageInfo, error)
+	ListProjectCommitPage(branch string, page int, opts ...CommitOption) ([]map[string]interface{}, PageInfo, error)
+	ListProjectCommitPageWithContext(ctx context.Context, branch string, page int, opts ...CommitOption) ([]map[string]interface{}, PageInfo, error)
+	ListTagsPage(page int) ([]map[string]interface{}, PageInfo, error)
+	ListTagsPageWithContext(ctx context.Context, page int) ([]map[string]interface{}, PageInfo, error)
+	TriggerPipeline(ref string) (int, error)
+	TriggerPipelineWithContext(ctx context.Context, ref string) (int, error)
+	ListPipelines(ref, status string) ([]map[string]interface{}, error)
+	ListPipelinesWithContext(ctx context.Context, ref, status string) ([]map[string]interface{}, error)
+	GetPipelineStatus(pipelineId int) (string, error)
+	GetPipelineStatusWithContext(ctx context.Context, pipelineId int) (string, error)
+	WaitForPipeline(pipelineId int, timeout time.Duration) (string, error)
+	WaitForPipelineWithContext(ctx context.Context, pipelineId int, timeout time.Duration) (string, error)
+	ListProjectFiltered(filter ProjectFilter) ([]map[string]interface{}, error)
+	ListProjectFilteredWithContext(ctx context.Context, filter ProjectFilter) ([]map[string]interface{}, error)
+	Provision(spec ProvisionSpec) (*ProvisionResult, error)
+	ProvisionWithContext(ctx context.Context, spec ProvisionSpec) (*ProvisionResult, error)
+	RateLimit() RateLimit
+	ListReleases() ([]map[string]interface{}, error)
+	ListReleasesWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	CreateRelease(tagName, name, description string, links ...ReleaseLink) (string, error)
+	CreateReleaseWithContext(ctx context.Context, tagName, name, description string, links ...ReleaseLink) (string, error)
+	ListTree(branch, path string, recursive bool) ([]map[string]interface{}, error)
+	ListTreeWithContext(ctx context.Context, branch, path string, recursive bool) ([]map[string]interface{}, error)
+	GetFileBlame(branch, filename string) ([]map[string]interface{}, error)
+	GetFileBlameWithContext(ctx context.Context, branch, filename string) ([]map[string]interface{}, error)
+	GetFileHistory(branch, filename string) ([]map[string]interface{}, error)
+	GetFileHistoryWithContext(ctx context.Context, branch, filename string) ([]map[string]interface{}, error)
+	ListProjectSnippets() ([]map[string]interface{}, error)
+	ListProjectSnippetsWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	CreateProjectSnippet(title, filename, content, visibility string) (int, error)
+	CreateProjectSnippetWithContext(ctx context.Context, title, filename, content, visibility string) (int, error)
+	DeleteProjectSnippet(snippetId int) (string, error)
+	DeleteProjectSnippetWithContext(ctx context.Context, snippetId int) (string, error)
+	GetRawFileStream(branch, filename string) (io.ReadCloser, error)
+	GetRawFileStreamWithContext(ctx context.Context, branch, filename string) (io.ReadCloser, error)
+	ListProjectVariables() ([]map[string]interface{}, error)
+	ListProjectVariablesWithContext(ctx context.Context) ([]map[string]interface{}, error)
+	CreateProjectVariable(key, value string, masked, protected bool) (string, error)
+	CreateProjectVariableWithContext(ctx context.Context, key, value string, masked, protected bool) (string, error)
+	UpdateProjectVariable(key, value string, masked, protected bool) (string, error)
+	UpdateProjectVariableWithContext(ctx context.Context, key, value string, masked, protected bool) (string, error)
+	DeleteProjectVariable(key string) (string, error)
+	DeleteProjectVariableWithContext(ctx context.Context, key string) (string, error)
+}
+
+var _ GitClient = (*gitlabServer)(nil)
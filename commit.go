@@ -0,0 +1,320 @@
+package git
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// CommitAction describe a single file change of a multi-file commit
+type CommitAction struct {
+	// Action is one of gitlab.FileCreate, gitlab.FileUpdate, gitlab.FileDelete or gitlab.FileMove
+	Action       gitlab.FileActionValue
+	FilePath     string
+	PreviousPath string
+	Content      string
+	// Encoding is "text" (default) or "base64"
+	Encoding string
+}
+
+// commitActionOptions convert actions to the go-gitlab commit actions
+func commitActionOptions(actions []CommitAction) []*gitlab.CommitActionOptions {
+	options := make([]*gitlab.CommitActionOptions, 0, len(actions))
+	for _, action := range actions {
+		option := &gitlab.CommitActionOptions{
+			Action:   gitlab.FileAction(action.Action),
+			FilePath: gitlab.String(action.FilePath),
+		}
+		if action.PreviousPath != "" {
+			option.PreviousPath = gitlab.String(action.PreviousPath)
+		}
+		if action.Action != gitlab.FileDelete {
+			option.Content = gitlab.String(action.Content)
+		}
+		if action.Encoding != "" {
+			option.Encoding = gitlab.String(action.Encoding)
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
+// CommitMultipleFiles create a single commit applying all actions and return its short id
+func (git *gitlabServer) CommitMultipleFiles(branch, commitMessage string, actions []CommitAction) (string, error) {
+	return git.CommitMultipleFilesWithContext(context.Background(), branch, commitMessage, actions)
+}
+
+// CommitMultipleFilesWithContext is like CommitMultipleFiles but binds the request to ctx
+func (git *gitlabServer) CommitMultipleFilesWithContext(ctx context.Context, branch, commitMessage string, actions []CommitAction) (string, error) {
+	if err := requireArgs("commit files", "branch", branch, "commitMessage", commitMessage); err != nil {
+		return "", err
+	}
+	if len(actions) == 0 {
+		return "", fmt.Errorf("commit to branch %s: no actions: %w", branch, ErrInvalidArgument)
+	}
+	if git.DryRun {
+		git.logger().Debugf("dry run: commit %d files to branch %s", len(actions), branch)
+		return "", nil
+	}
+	options := &gitlab.CreateCommitOptions{
+		Branch:        gitlab.String(branch),
+		CommitMessage: gitlab.String(git.commitMessage(commitMessage)),
+		Actions:       commitActionOptions(actions),
+	}
+	options.AuthorName, options.AuthorEmail = git.author()
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return "", err
+	}
+	var commit *gitlab.Commit
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		commit, resp, err = git.api.Commits.CreateCommit(projectPath, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return "", fmt.Errorf("commit %d files to branch %s: %w", len(actions), branch, err)
+	}
+	return commit.ShortID, nil
+}
+
+// CreateFilesInter Create all files rendered by f in a single commit
+func (git *gitlabServer) CreateFilesInter(branch string, f multiFileContentInter, commitMessage string) (string, error) {
+	return git.CreateFilesInterWithContext(context.Background(), branch, f, commitMessage)
+}
+
+// CreateFilesInterWithContext is like CreateFilesInter but binds the request to ctx
+func (git *gitlabServer) CreateFilesInterWithContext(ctx context.Context, branch string, f multiFileContentInter, commitMessage string) (string, error) {
+	files, err := f.RenderFiles()
+	if err != nil {
+		return "", fmt.Errorf("renderFiles interface err: %w", err)
+	}
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	actions := make([]CommitAction, 0, len(filenames))
+	for _, filename := range filenames {
+		actions = append(actions, CommitAction{
+			Action:   gitlab.FileCreate,
+			FilePath: filename,
+			Content:  string(files[filename]),
+		})
+	}
+	return git.CommitMultipleFilesWithContext(ctx, branch, commitMessage, actions)
+}
+
+// CompareRefs compare two refs and return the commits and diffs between them,
+// straight compares from and to directly instead of from their merge base
+func (git *gitlabServer) CompareRefs(from, to string, straight bool) (map[string]interface{}, error) {
+	return git.CompareRefsWithContext(context.Background(), from, to, straight)
+}
+
+// CompareRefsWithContext is like CompareRefs but binds the request to ctx
+func (git *gitlabServer) CompareRefsWithContext(ctx context.Context, from, to string, straight bool) (map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.CompareOptions{
+		From:     &from,
+		To:       &to,
+		Straight: &straight,
+	}
+	var compare *gitlab.Compare
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		compare, resp, err = git.api.Repositories.Compare(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(compare)
+}
+
+// GetCommit get a single commit with its stats, parents and web_url
+func (git *gitlabServer) GetCommit(sha string) (map[string]interface{}, error) {
+	return git.GetCommitWithContext(context.Background(), sha)
+}
+
+// GetCommitWithContext is like GetCommit but binds the request to ctx
+func (git *gitlabServer) GetCommitWithContext(ctx context.Context, sha string) (map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var commit *gitlab.Commit
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		commit, resp, err = git.api.Commits.GetCommit(int(projectId), sha, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("commit %s: %w", sha, ErrCommitNotFound)
+		}
+		return nil, err
+	}
+	return convertToMap(commit)
+}
+
+// GetCommitDiff get the diff of a commit, one entry per changed file with old_path, new_path and diff
+func (git *gitlabServer) GetCommitDiff(sha string) ([]map[string]interface{}, error) {
+	return git.GetCommitDiffWithContext(context.Background(), sha)
+}
+
+// GetCommitDiffWithContext is like GetCommitDiff but binds the request to ctx
+func (git *gitlabServer) GetCommitDiffWithContext(ctx context.Context, sha string) ([]map[string]interface{}, error) {
+	if err := requireArgs("get commit diff", "sha", sha); err != nil {
+		return nil, err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := gitlab.GetCommitDiffOptions(git.listOptions())
+	var diffSlice []*gitlab.Diff
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("get commit diff: exceeded max pages %d", git.maxPages())
+		}
+		var diffs []*gitlab.Diff
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			diffs, resp, err = git.api.Commits.GetCommitDiff(int(projectId), sha, &options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				return nil, fmt.Errorf("commit %s: %w", sha, ErrCommitNotFound)
+			}
+			return nil, err
+		}
+		diffSlice = append(diffSlice, diffs...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(diffSlice)
+}
+
+// GetLatestCommit get the head commit of the branch
+func (git *gitlabServer) GetLatestCommit(branch string) (map[string]interface{}, error) {
+	return git.GetLatestCommitWithContext(context.Background(), branch)
+}
+
+// GetLatestCommitWithContext is like GetLatestCommit but binds the request to ctx
+func (git *gitlabServer) GetLatestCommitWithContext(ctx context.Context, branch string) (map[string]interface{}, error) {
+	if err := requireArgs("get latest commit", "branch", branch); err != nil {
+		return nil, err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var b *gitlab.Branch
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		b, resp, err = git.api.Branches.GetBranch(int(projectId), branch, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("branch %s: %w", branch, ErrBranchNotFound)
+		}
+		return nil, err
+	}
+	return convertToMap(b.Commit)
+}
+
+// GetCommitSignature get the GPG signature of a commit, including verification_status and signing key info
+func (git *gitlabServer) GetCommitSignature(sha string) (map[string]interface{}, error) {
+	return git.GetCommitSignatureWithContext(context.Background(), sha)
+}
+
+// GetCommitSignatureWithContext is like GetCommitSignature but binds the request to ctx
+func (git *gitlabServer) GetCommitSignatureWithContext(ctx context.Context, sha string) (map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var signature *gitlab.GPGSignature
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		signature, resp, err = git.api.Commits.GetGPGSiganature(int(projectId), sha, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// the signature endpoint answers 404 for both unknown and unsigned commits
+			if _, err := git.GetCommitWithContext(ctx, sha); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("commit %s: %w", sha, ErrCommitUnsigned)
+		}
+		return nil, err
+	}
+	return convertToMap(signature)
+}
+
+// CherryPickCommit cherry-pick a commit onto the target branch and return the new commit id
+func (git *gitlabServer) CherryPickCommit(sha, targetBranch string) (string, error) {
+	return git.CherryPickCommitWithContext(context.Background(), sha, targetBranch)
+}
+
+// CherryPickCommitWithContext is like CherryPickCommit but binds the request to ctx
+func (git *gitlabServer) CherryPickCommitWithContext(ctx context.Context, sha, targetBranch string) (string, error) {
+	if err := requireArgs("cherry-pick commit", "sha", sha, "targetBranch", targetBranch); err != nil {
+		return "", err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	options := &gitlab.CherryPickCommitOptions{
+		Branch: &targetBranch,
+	}
+	var commit *gitlab.Commit
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		commit, resp, err = git.api.Commits.CherryPickCommit(int(projectId), sha, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusBadRequest {
+			return "", fmt.Errorf("cherry-pick %s onto %s: %v: %w", sha, targetBranch, err, ErrCherryPickConflict)
+		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("commit %s: %w", sha, ErrCommitNotFound)
+		}
+		return "", err
+	}
+	return commit.ID, nil
+}
+
+// MoveFile move a file to newPath in a single commit, the content is carried over base64
+// encoded so it is preserved byte for byte
+func (git *gitlabServer) MoveFile(branch, oldPath, newPath, commitMessage string) (string, error) {
+	return git.MoveFileWithContext(context.Background(), branch, oldPath, newPath, commitMessage)
+}
+
+// MoveFileWithContext is like MoveFile but binds the request to ctx
+func (git *gitlabServer) MoveFileWithContext(ctx context.Context, branch, oldPath, newPath, commitMessage string) (string, error) {
+	content, err := git.GetRawFileAtRefWithContext(ctx, branch, oldPath)
+	if err != nil {
+		return "", err
+	}
+	actions := []CommitAction{
+		{
+			Action:   gitlab.FileDelete,
+			FilePath: oldPath,
+		},
+		{
+			Action:   gitlab.FileCreate,
+			FilePath: newPath,
+			Content:  base64.StdEncoding.EncodeToString([]byte(content)),
+			Encoding: "base64",
+		},
+	}
+	return git.CommitMultipleFilesWithContext(ctx, branch, commitMessage, actions)
+}
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// CommitOption override a field of the options used to list commits
+type CommitOption func(*gitlab.ListCommitsOptions)
+
+// WithCommitsSince only list commits after since
+func WithCommitsSince(since time.Time) CommitOption {
+	return func(o *gitlab.ListCommitsOptions) {
+		o.Since = gitlab.Time(since)
+	}
+}
+
+// WithCommitsUntil only list commits before until
+func WithCommitsUntil(until time.Time) CommitOption {
+	return func(o *gitlab.ListCommitsOptions) {
+		o.Until = gitlab.Time(until)
+	}
+}
+
+// WithCommitsPage start listing from page instead of the first one
+func WithCommitsPage(page int) CommitOption {
+	return func(o *gitlab.ListCommitsOptions) {
+		o.Page = page
+	}
+}
+
+// WithCommitsPerPage set the page size used while listing commits
+func WithCommitsPerPage(perPage int) CommitOption {
+	return func(o *gitlab.ListCommitsOptions) {
+		o.PerPage = perPage
+	}
+}
+
+// WithCommitsPath only list commits touching path, a file or a directory
+func WithCommitsPath(path string) CommitOption {
+	return func(o *gitlab.ListCommitsOptions) {
+		o.Path = gitlab.String(path)
+	}
+}
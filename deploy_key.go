@@ -0,0 +1,93 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListDeployKeys list the deploy keys of the project
+func (git *gitlabServer) ListDeployKeys() ([]map[string]interface{}, error) {
+	return git.ListDeployKeysWithContext(context.Background())
+}
+
+// ListDeployKeysWithContext is like ListDeployKeys but binds the request to ctx
+func (git *gitlabServer) ListDeployKeysWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := gitlab.ListProjectDeployKeysOptions(git.listOptions())
+	var keySlice []*gitlab.ProjectDeployKey
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list deploy keys: exceeded max pages %d", git.maxPages())
+		}
+		var keys []*gitlab.ProjectDeployKey
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			keys, resp, err = git.api.DeployKeys.ListProjectDeployKeys(int(projectId), &options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		keySlice = append(keySlice, keys...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(keySlice)
+}
+
+// AddDeployKey add a deploy key to the project and return its id
+func (git *gitlabServer) AddDeployKey(title, key string, canPush bool) (int, error) {
+	return git.AddDeployKeyWithContext(context.Background(), title, key, canPush)
+}
+
+// AddDeployKeyWithContext is like AddDeployKey but binds the request to ctx
+func (git *gitlabServer) AddDeployKeyWithContext(ctx context.Context, title, key string, canPush bool) (int, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	options := &gitlab.AddDeployKeyOptions{
+		Title:   &title,
+		Key:     &key,
+		CanPush: &canPush,
+	}
+	var deployKey *gitlab.ProjectDeployKey
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		deployKey, resp, err = git.api.DeployKeys.AddDeployKey(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if isNameTaken(resp, err) {
+			return 0, fmt.Errorf("deploy key %s: %w", title, ErrDeployKeyExists)
+		}
+		return 0, err
+	}
+	return deployKey.ID, nil
+}
+
+// EnableDeployKey enable an existing deploy key, e.g. one added to another project, on the project
+func (git *gitlabServer) EnableDeployKey(keyId int) (string, error) {
+	return git.EnableDeployKeyWithContext(context.Background(), keyId)
+}
+
+// EnableDeployKeyWithContext is like EnableDeployKey but binds the request to ctx
+func (git *gitlabServer) EnableDeployKeyWithContext(ctx context.Context, keyId int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.DeployKeys.EnableDeployKey(int(projectId), keyId, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("enable deploy key: <%d> error", keyId), err
+	}
+	return fmt.Sprintf("enable deploy key: <%d> ok", keyId), nil
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListEnvironments list the environments of the project
+func (git *gitlabServer) ListEnvironments() ([]map[string]interface{}, error) {
+	return git.ListEnvironmentsWithContext(context.Background())
+}
+
+// ListEnvironmentsWithContext is like ListEnvironments but binds the request to ctx
+func (git *gitlabServer) ListEnvironmentsWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListEnvironmentsOptions{ListOptions: git.listOptions()}
+	var environmentSlice []*gitlab.Environment
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list environments: exceeded max pages %d", git.maxPages())
+		}
+		var environments []*gitlab.Environment
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			environments, resp, err = git.api.Environments.ListEnvironments(int(projectId), options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		environmentSlice = append(environmentSlice, environments...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(environmentSlice)
+}
+
+// CreateEnvironment create an environment of the project and return its id
+func (git *gitlabServer) CreateEnvironment(name, externalURL string) (int, error) {
+	return git.CreateEnvironmentWithContext(context.Background(), name, externalURL)
+}
+
+// CreateEnvironmentWithContext is like CreateEnvironment but binds the request to ctx
+func (git *gitlabServer) CreateEnvironmentWithContext(ctx context.Context, name, externalURL string) (int, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	options := &gitlab.CreateEnvironmentOptions{Name: &name}
+	if externalURL != "" {
+		options.ExternalURL = &externalURL
+	}
+	var environment *gitlab.Environment
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		environment, resp, err = git.api.Environments.CreateEnvironment(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return environment.ID, nil
+}
+
+// StopEnvironment stop an environment of the project
+func (git *gitlabServer) StopEnvironment(envId int) (string, error) {
+	return git.StopEnvironmentWithContext(context.Background(), envId)
+}
+
+// StopEnvironmentWithContext is like StopEnvironment but binds the request to ctx
+func (git *gitlabServer) StopEnvironmentWithContext(ctx context.Context, envId int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.Environments.StopEnvironment(int(projectId), envId, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("stop environment: <%d> error", envId), err
+	}
+	return fmt.Sprintf("stop environment: <%d> ok", envId), nil
+}
@@ -0,0 +1,62 @@
+package git
+
+import "errors"
+
+var (
+	// ErrClientNotInitialized is returned when the server has no client, e.g. InitGitlabServer was not called
+	ErrClientNotInitialized = errors.New("gitlab client not initialized")
+	// ErrUnauthorized is returned when GitLab rejects the token
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrUnreachable is returned when GitLab could not be reached at all
+	ErrUnreachable = errors.New("gitlab unreachable")
+	// ErrInvalidArgument is returned when a required argument is empty
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrGroupNotFound is returned when the group path or id does not exist
+	ErrGroupNotFound = errors.New("group not found")
+	// ErrProjectNotFound is returned when the project does not exist in the group
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrProjectExists is returned when a project with the same name already exists in the namespace
+	ErrProjectExists = errors.New("project already exists")
+	// ErrImportFailed is returned when the import or fork of a project failed
+	ErrImportFailed = errors.New("project import failed")
+	// ErrHookNotFound is returned when no project hook matches the url
+	ErrHookNotFound = errors.New("hook not found")
+	// ErrHookExists is returned when the project already has a hook for the url
+	ErrHookExists = errors.New("hook already exists")
+	// ErrFileNotFound is returned when the file does not exist on the ref
+	ErrFileNotFound = errors.New("file not found")
+	// ErrBranchNotFound is returned when the branch does not exist
+	ErrBranchNotFound = errors.New("branch not found")
+	// ErrBranchExists is returned when a branch of the same name already exists
+	ErrBranchExists = errors.New("branch already exists")
+	// ErrBranchProtected is returned when refusing to delete a protected or default branch without force
+	ErrBranchProtected = errors.New("branch is protected or default")
+	// ErrTagNotFound is returned when the tag does not exist in the project
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrTagExists is returned when a tag of the same name already points at another commit
+	ErrTagExists = errors.New("tag already exists")
+	// ErrTagAlreadyProtected is returned when the tag pattern is already protected
+	ErrTagAlreadyProtected = errors.New("tag already protected")
+	// ErrCommitNotFound is returned when the commit sha is unknown to the project
+	ErrCommitNotFound = errors.New("commit not found")
+	// ErrCommitUnsigned is returned when the commit carries no GPG signature
+	ErrCommitUnsigned = errors.New("commit is not signed")
+	// ErrArtifactsNotFound is returned when the job does not exist or kept no artifacts
+	ErrArtifactsNotFound = errors.New("artifacts not found")
+	// ErrCherryPickConflict is returned when the commit can not be cherry-picked cleanly
+	ErrCherryPickConflict = errors.New("cherry-pick conflict")
+	// ErrMergeConflict is returned when the merge request cannot be merged, e.g. because of conflicts
+	ErrMergeConflict = errors.New("merge request cannot be merged")
+	// ErrNotFastForward is returned when the merge request cannot be merged as a fast-forward
+	ErrNotFastForward = errors.New("merge is not a fast-forward")
+	// ErrMemberExists is returned when the user is already a member of the project
+	ErrMemberExists = errors.New("member already exists")
+	// ErrDeployKeyExists is returned when the deploy key is already added to the project
+	ErrDeployKeyExists = errors.New("deploy key already exists")
+	// ErrPermissionDenied is returned when the token lacks the access level the operation needs
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrPremiumRequired is returned when the feature is not available in the GitLab edition
+	ErrPremiumRequired = errors.New("feature requires GitLab Premium")
+	// ErrNoChanges is returned when the source branch has nothing to merge into the target branch
+	ErrNoChanges = errors.New("no changes between branches")
+)
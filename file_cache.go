@@ -0,0 +1,129 @@
+package git
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// fileCacheKey identify a file content, the blob id changes whenever the content does
+type fileCacheKey struct {
+	project string
+	ref     string
+	path    string
+	blobId  string
+}
+
+type fileCacheEntry struct {
+	key     fileCacheKey
+	content []byte
+}
+
+// fileCache is a least recently used cache of raw file contents bounded in bytes, it is safe for concurrent use
+type fileCache struct {
+	mu      sync.Mutex
+	entries map[fileCacheKey]*list.Element
+	order   *list.List
+	size    int64
+}
+
+func newFileCache() *fileCache {
+	return &fileCache{entries: make(map[fileCacheKey]*list.Element), order: list.New()}
+}
+
+func (c *fileCache) get(key fileCacheKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*fileCacheEntry).content, true
+}
+
+// set store content and evict the least recently used entries beyond maxBytes,
+// content larger than maxBytes is not cached
+func (c *fileCache) set(key fileCacheKey, content []byte, maxBytes int64) {
+	if int64(len(content)) > maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&fileCacheEntry{key: key, content: append([]byte(nil), content...)})
+	c.size += int64(len(content))
+	for c.size > maxBytes {
+		oldest := c.order.Back()
+		entry := c.order.Remove(oldest).(*fileCacheEntry)
+		delete(c.entries, entry.key)
+		c.size -= int64(len(entry.content))
+	}
+}
+
+func (c *fileCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[fileCacheKey]*list.Element)
+	c.order.Init()
+	c.size = 0
+}
+
+// fileCacheEnabled report whether GetRawFile may serve contents from the cache
+func (git *gitlabServer) fileCacheEnabled() bool {
+	return git.files != nil && git.FileCacheMaxBytes > 0
+}
+
+// lookupFileCache resolve the blob id of the file at ref and look its content up, the
+// returned key is zero when the cache is disabled or the blob id could not be resolved
+func (git *gitlabServer) lookupFileCache(ctx context.Context, ref, filename string) (fileCacheKey, []byte, bool) {
+	if !git.fileCacheEnabled() {
+		return fileCacheKey{}, nil, false
+	}
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return fileCacheKey{}, nil, false
+	}
+	gf := &gitlab.GetFileMetaDataOptions{Ref: gitlab.String(ref)}
+	var file *gitlab.File
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		file, resp, err = git.api.RepositoryFiles.GetFileMetaData(projectPath, filename, gf, optionFuncs...)
+		return
+	})
+	if err != nil {
+		// let the raw request report the failure
+		return fileCacheKey{}, nil, false
+	}
+	key := fileCacheKey{project: projectPath, ref: ref, path: filename, blobId: file.BlobID}
+	content, ok := git.files.get(key)
+	if ok {
+		git.logger().Debugf("file %s on ref %s served from cache", filename, ref)
+	}
+	return key, content, ok
+}
+
+// storeFileCache cache content under key, the blob id the raw response reports wins over
+// the looked up one in case the file changed in between, a zero key is ignored
+func (git *gitlabServer) storeFileCache(key fileCacheKey, resp *gitlab.Response, content []byte) {
+	if key.blobId == "" || !git.fileCacheEnabled() {
+		return
+	}
+	if resp != nil && resp.Response != nil {
+		if blobId := resp.Header.Get("X-Gitlab-Blob-Id"); blobId != "" {
+			key.blobId = blobId
+		}
+	}
+	git.files.set(key, content, git.FileCacheMaxBytes)
+}
+
+// InvalidateFileCache drop all cached file contents
+func (git *gitlabServer) InvalidateFileCache() {
+	if git.files != nil {
+		git.files.clear()
+	}
+}
@@ -1,9 +1,15 @@
 package git
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/xanzy/go-gitlab"
 )
@@ -14,25 +20,225 @@ type fileContentInter interface {
 	RenderYaml() ([]byte, error)
 }
 
+// jsonFileContentInter render a file as JSON, optionally implemented next to RenderYaml
+type jsonFileContentInter interface {
+	RenderJSON() ([]byte, error)
+}
+
+// multiFileContentInter render several files at once, keyed by filename
+type multiFileContentInter interface {
+	RenderFiles() (map[string][]byte, error)
+}
+
+const (
+	defaultPerPage  = 100
+	defaultMaxPages = 100
+)
+
 type gitlabServer struct {
 	Client      *gitlab.Client
 	GroupId     *int
 	GroupName   string
 	ProjectName string
+	// PerPage is the page size used by list requests, defaults to 100
+	PerPage int
+	// MaxPages bounds how many pages a list request may walk, defaults to 100
+	MaxPages int
+	// MaxRetries is how many times a rate-limited or 5xx request is retried, zero disables retry
+	MaxRetries int
+	// BaseBackoff is the first retry delay, doubled on every attempt, defaults to 500ms
+	BaseBackoff time.Duration
+	// Logger receive diagnostics, defaults to a no-op logger
+	Logger Logger
+	// DryRun make the mutating operations log the intended action and return a synthetic
+	// success without calling the API
+	DryRun bool
+	// PollInterval is how often the WaitFor methods poll GitLab, defaults to 5s
+	PollInterval time.Duration
+	// ProjectIdCacheTTL is how long GetProjectId caches a resolved id, defaults to 5m, negative disables it
+	ProjectIdCacheTTL time.Duration
+	// ProjectMatch select whether ProjectName is looked up by project name, path or either
+	ProjectMatch ProjectMatch
+	// ProjectMatchIgnoreCase make the ProjectName lookup case-insensitive
+	ProjectMatchIgnoreCase bool
+	// FullProjectData make ListProject, and the lookups built on it, request the full project
+	// data, e.g. last_activity_at and permissions, instead of the faster simple view
+	FullProjectData bool
+	// Keyset switch ListProject and the commit listing to keyset pagination, nil keeps offset pagination
+	Keyset *KeysetPagination
+	// AuthorName and AuthorEmail attribute the commits of the file operations to someone
+	// other than the token owner, unset keeps GitLab's default
+	AuthorName  string
+	AuthorEmail string
+	// CommitMessagePrefix is prepended to the message of every commit the file operations make, e.g. "[bot] "
+	CommitMessagePrefix string
+	// HookToken is the secret set on project hooks created without a token, GitLab sends it
+	// as X-Gitlab-Token so receivers can authenticate the payloads, unset creates no secret
+	HookToken string
+	// FileCacheMaxBytes bounds the content cache GetRawFile and GetRawFileAtRef use to skip
+	// downloading unchanged files, zero disables it
+	FileCacheMaxBytes int64
+	// Timeout bounds each call including its retries, defaults to 30s, negative disables it,
+	// a deadline already set on the ctx passed to the WithContext methods takes precedence
+	Timeout time.Duration
+
+	// api is the go-gitlab services of Client, replaced by fakes in tests
+	api *gitlabServices
+	// projectIds cache the ids resolved by GetProjectId, nil disables caching
+	projectIds *projectIdCache
+	// files cache raw file contents by blob id for GetRawFile, nil disables caching
+	files *fileCache
+	// rateLimit hold the rate-limit headers of the last response, nil disables tracking
+	rateLimit *rateLimitState
+}
+
+// NewGitlabServer create an independent gitlab server with its own client, options are
+// forwarded to go-gitlab, e.g. gitlab.WithHTTPClient for a proxy, custom CA or timeout
+func NewGitlabServer(token, url string, options ...gitlab.ClientOptionFunc) (GitClient, error) {
+	client, err := gitlab.NewClient(token, withBaseURL(url, options)...)
+	if err != nil {
+		return nil, err
+	}
+	return newGitlabServer(client), nil
+}
+
+// NewGitlabServerWithOAuth create an independent gitlab server authenticated by an OAuth token
+func NewGitlabServerWithOAuth(token, url string, options ...gitlab.ClientOptionFunc) (GitClient, error) {
+	client, err := gitlab.NewOAuthClient(token, withBaseURL(url, options)...)
+	if err != nil {
+		return nil, err
+	}
+	return newGitlabServer(client), nil
+}
+
+// NewGitlabServerWithJobToken create an independent gitlab server authenticated by a CI job token
+func NewGitlabServerWithJobToken(token, url string, options ...gitlab.ClientOptionFunc) (GitClient, error) {
+	client, err := gitlab.NewJobClient(token, withBaseURL(url, options)...)
+	if err != nil {
+		return nil, err
+	}
+	return newGitlabServer(client), nil
+}
+
+// withBaseURL prepend the base url option to options
+func withBaseURL(url string, options []gitlab.ClientOptionFunc) []gitlab.ClientOptionFunc {
+	// go-gitlab keeps the path of a relative url install, e.g. https://host/gitlab, and appends api/v4
+	return append([]gitlab.ClientOptionFunc{gitlab.WithBaseURL(normalizeBaseURL(url))}, options...)
+}
+
+// normalizeBaseURL trim the spaces and trailing slashes around url
+func normalizeBaseURL(url string) string {
+	// go-gitlab adds a single slash back, more would end up as //api/v4
+	return strings.TrimRight(strings.TrimSpace(url), "/")
+}
+
+// newGitlabServer create a gitlab server around client
+func newGitlabServer(client *gitlab.Client) *gitlabServer {
+	return &gitlabServer{Client: client, api: newGitlabServices(client), projectIds: newProjectIdCache(), files: newFileCache(), rateLimit: &rateLimitState{}}
+}
+
+// InitGitlabServer init the package level GitlabServer
+func InitGitlabServer(token, url string, options ...gitlab.ClientOptionFunc) error {
+	client, err := gitlab.NewClient(token, withBaseURL(url, options)...)
+	if err != nil {
+		return err
+	}
+	initGitlabServer(newGitlabServer(client))
+	return nil
+}
+
+// InitGitlabServerWithOAuth init the package level GitlabServer with an OAuth token
+func InitGitlabServerWithOAuth(token, url string, options ...gitlab.ClientOptionFunc) error {
+	client, err := gitlab.NewOAuthClient(token, withBaseURL(url, options)...)
+	if err != nil {
+		return err
+	}
+	initGitlabServer(newGitlabServer(client))
+	return nil
 }
 
-// InitGitlabServer init gitlab
-func InitGitlabServer(token, url string) error {
-	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(url))
+// InitGitlabServerWithJobToken init the package level GitlabServer with a CI job token
+func InitGitlabServerWithJobToken(token, url string, options ...gitlab.ClientOptionFunc) error {
+	client, err := gitlab.NewJobClient(token, withBaseURL(url, options)...)
 	if err != nil {
 		return err
 	}
-	GitlabServer.Client = client
+	initGitlabServer(newGitlabServer(client))
 	return nil
 }
 
-// CreateProject Create a new project
-func (git *gitlabServer) CreateProject() (string, error) {
+// initGitlabServer point the package level GitlabServer at server's client
+func initGitlabServer(server *gitlabServer) {
+	GitlabServer.Client = server.Client
+	GitlabServer.api = server.api
+	GitlabServer.projectIds = server.projectIds
+	GitlabServer.files = server.files
+	GitlabServer.rateLimit = server.rateLimit
+}
+
+// WithProject return a copy of the server targeting projectName, the receiver is left
+// untouched so goroutines can work on different projects through one client without
+// sharing mutable state
+func (git *gitlabServer) WithProject(projectName string) GitClient {
+	return git.withProject(projectName)
+}
+
+// withProject is WithProject returning the concrete server
+func (git *gitlabServer) withProject(projectName string) *gitlabServer {
+	server := *git
+	server.ProjectName = projectName
+	return &server
+}
+
+// WithGroup return a copy of the server targeting the group, see WithProject
+func (git *gitlabServer) WithGroup(groupId int, groupName string) GitClient {
+	return git.withGroup(groupId, groupName)
+}
+
+// withGroup is WithGroup returning the concrete server
+func (git *gitlabServer) withGroup(groupId int, groupName string) *gitlabServer {
+	server := *git
+	server.GroupId = &groupId
+	server.GroupName = groupName
+	return &server
+}
+
+// WithAuthor return a copy of the server attributing file commits to the author, see WithProject
+func (git *gitlabServer) WithAuthor(name, email string) GitClient {
+	server := *git
+	server.AuthorName = name
+	server.AuthorEmail = email
+	return &server
+}
+
+// commitMessage return msg with CommitMessagePrefix applied
+func (git *gitlabServer) commitMessage(msg string) string {
+	return git.CommitMessagePrefix + msg
+}
+
+// author return the commit author override, nil for the unset parts
+func (git *gitlabServer) author() (name, email *string) {
+	if git.AuthorName != "" {
+		name = gitlab.String(git.AuthorName)
+	}
+	if git.AuthorEmail != "" {
+		email = gitlab.String(git.AuthorEmail)
+	}
+	return
+}
+
+// CreateProject Create a new project and return its id, opts override the default private
+// manifests project settings
+func (git *gitlabServer) CreateProject(opts ...ProjectOption) (int, string, error) {
+	return git.CreateProjectWithContext(context.Background(), opts...)
+}
+
+// CreateProjectWithContext is like CreateProject but binds the request to ctx
+func (git *gitlabServer) CreateProjectWithContext(ctx context.Context, opts ...ProjectOption) (int, string, error) {
+	if git.DryRun {
+		git.logger().Debugf("dry run: create project %s", git.ProjectName)
+		return 0, fmt.Sprintf("create project: <%v> ok, dry run", git.ProjectName), nil
+	}
 	p := &gitlab.CreateProjectOptions{
 		NamespaceID:          git.GroupId,
 		Name:                 gitlab.String(git.ProjectName),
@@ -41,22 +247,186 @@ func (git *gitlabServer) CreateProject() (string, error) {
 		SnippetsEnabled:      gitlab.Bool(true),
 		Visibility:           gitlab.Visibility(gitlab.PrivateVisibility),
 	}
-	project, _, err := git.Client.Projects.CreateProject(p)
+	for _, opt := range opts {
+		opt(p)
+	}
+	var project *gitlab.Project
+	_, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		project, resp, err = git.api.Projects.CreateProject(p, optionFuncs...)
+		return
+	})
 	if err != nil {
-		return fmt.Sprintf("create project: <%v> error", git.ProjectName), err
+		return 0, fmt.Sprintf("create project: <%v> error", git.ProjectName), err
 	}
-	return fmt.Sprintf("create project: <%v> ok, project_id: %d", git.ProjectName, project.ID), nil
+	return project.ID, fmt.Sprintf("create project: <%v> ok, project_id: %d", git.ProjectName, project.ID), nil
 }
 
-// ListProjectHook list a project's hook
-func (git *gitlabServer) ListProjectHook() (data []map[string]interface{}, err error) {
-	repoInfo, err := git.GetProject()
+// DeleteProject delete the project and report whether GitLab only scheduled the deletion
+func (git *gitlabServer) DeleteProject() (bool, string, error) {
+	return git.DeleteProjectWithContext(context.Background())
+}
+
+// DeleteProjectWithContext is like DeleteProject but binds the request to ctx
+func (git *gitlabServer) DeleteProjectWithContext(ctx context.Context) (bool, string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
 	if err != nil {
+		return false, "get project id error", err
+	}
+	return git.DeleteProjectByIdWithContext(ctx, int(projectId))
+}
+
+// DeleteProjectById delete the project by project id and report whether GitLab only scheduled the deletion
+func (git *gitlabServer) DeleteProjectById(id int) (bool, string, error) {
+	return git.DeleteProjectByIdWithContext(context.Background(), id)
+}
+
+// DeleteProjectByIdWithContext is like DeleteProjectById but binds the request to ctx
+func (git *gitlabServer) DeleteProjectByIdWithContext(ctx context.Context, id int) (bool, string, error) {
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.Projects.DeleteProject(id, optionFuncs...)
 		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, fmt.Sprintf("delete project: <%d> error", id), fmt.Errorf("project id %d: %w", id, ErrProjectNotFound)
+		}
+		return false, fmt.Sprintf("delete project: <%d> error, err: %v", id, respStatus(resp, err)), err
 	}
-	repoId := repoInfo["id"]
-	p := &gitlab.ListProjectHooksOptions{}
-	projectHooks, _, err := git.Client.Projects.ListProjectHooks(repoId, p)
+	if git.projectIds != nil {
+		git.projectIds.removeId(float64(id))
+	}
+	// GitLab answers 202 for immediate and delayed deletion alike, only a project still
+	// readable and marked for deletion tells them apart
+	var project *gitlab.Project
+	resp, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		project, resp, err = git.api.Projects.GetProject(id, &gitlab.GetProjectOptions{}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, fmt.Sprintf("delete project: <%d> ok", id), nil
+		}
+		return false, fmt.Sprintf("delete project: <%d> ok, deletion state unknown", id), fmt.Errorf("project id %d deleted, read deletion state: %w", id, err)
+	}
+	if project.MarkedForDeletionAt != nil {
+		return true, fmt.Sprintf("delete project: <%d> ok, marked for deletion on %s", id, time.Time(*project.MarkedForDeletionAt).Format("2006-01-02")), nil
+	}
+	return false, fmt.Sprintf("delete project: <%d> ok", id), nil
+}
+
+// ForkProject fork the project into the namespace as newName at newPath and return the fork's id,
+// an empty newPath keeps the source project's path
+func (git *gitlabServer) ForkProject(namespaceId int, newName, newPath string) (int, error) {
+	return git.ForkProjectWithContext(context.Background(), namespaceId, newName, newPath)
+}
+
+// ForkProjectWithContext is like ForkProject but binds the request to ctx
+func (git *gitlabServer) ForkProjectWithContext(ctx context.Context, namespaceId int, newName, newPath string) (int, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	options := &gitlab.ForkProjectOptions{
+		NamespaceID: gitlab.Int(namespaceId),
+		Name:        gitlab.String(newName),
+	}
+	if newPath != "" {
+		options.Path = gitlab.String(newPath)
+	}
+	var project *gitlab.Project
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		project, resp, err = git.api.Projects.ForkProject(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if isNameTaken(resp, err) {
+			return 0, fmt.Errorf("fork %s as %s into namespace %d: %w", git.ProjectName, newName, namespaceId, ErrProjectExists)
+		}
+		return 0, err
+	}
+	return project.ID, nil
+}
+
+// TransferProject move the project into another namespace
+func (git *gitlabServer) TransferProject(targetNamespaceId int) (string, error) {
+	return git.TransferProjectWithContext(context.Background(), targetNamespaceId)
+}
+
+// TransferProjectWithContext is like TransferProject but binds the request to ctx
+func (git *gitlabServer) TransferProjectWithContext(ctx context.Context, targetNamespaceId int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.TransferProjectOptions{
+		Namespace: targetNamespaceId,
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Projects.TransferProject(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if isNameTaken(resp, err) {
+			return fmt.Sprintf("transfer project: <%v> error", git.ProjectName), fmt.Errorf("project %s in namespace %d: %w", git.ProjectName, targetNamespaceId, ErrProjectExists)
+		}
+		return fmt.Sprintf("transfer project: <%v> error", git.ProjectName), err
+	}
+	if git.projectIds != nil {
+		git.projectIds.removeId(projectId)
+	}
+	return fmt.Sprintf("transfer project: <%v> ok, namespace_id: %d", git.ProjectName, targetNamespaceId), nil
+}
+
+// EditProject change the project settings after creation, e.g. merge method, squash option or topics
+func (git *gitlabServer) EditProject(opts *gitlab.EditProjectOptions) (string, error) {
+	return git.EditProjectWithContext(context.Background(), opts)
+}
+
+// EditProjectWithContext is like EditProject but binds the request to ctx
+func (git *gitlabServer) EditProjectWithContext(ctx context.Context, opts *gitlab.EditProjectOptions) (string, error) {
+	if opts == nil {
+		return fmt.Sprintf("edit project: <%v> error", git.ProjectName), fmt.Errorf("edit project: options are nil: %w", ErrInvalidArgument)
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Projects.EditProject(int(projectId), opts, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if isNameTaken(resp, err) {
+			return fmt.Sprintf("edit project: <%v> error", git.ProjectName), fmt.Errorf("project %s: %w", git.ProjectName, ErrProjectExists)
+		}
+		return fmt.Sprintf("edit project: <%v> error", git.ProjectName), err
+	}
+	if opts.Name != nil && git.projectIds != nil {
+		// a renamed project no longer resolves under the old name
+		git.projectIds.removeId(projectId)
+	}
+	return fmt.Sprintf("edit project: <%v> ok", git.ProjectName), nil
+}
+
+// isNameTaken report whether GitLab refused the request because the name or path is already in use
+func isNameTaken(resp *gitlab.Response, err error) bool {
+	if resp == nil || resp.Response == nil {
+		return false
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return true
+	}
+	return resp.StatusCode == http.StatusBadRequest && strings.Contains(err.Error(), "has already been taken")
+}
+
+// ListProjectHook list a project's hook
+func (git *gitlabServer) ListProjectHook() (data []map[string]interface{}, err error) {
+	return git.ListProjectHookWithContext(context.Background())
+}
+
+// ListProjectHookWithContext is like ListProjectHook but binds the request to ctx
+func (git *gitlabServer) ListProjectHookWithContext(ctx context.Context) (data []map[string]interface{}, err error) {
+	projectHooks, err := git.listProjectHooks(ctx)
 	if err != nil {
 		return
 	}
@@ -71,154 +441,642 @@ func (git *gitlabServer) ListProjectHook() (data []map[string]interface{}, err e
 	return
 }
 
+// listProjectHooks list the project's hooks as returned by the API
+func (git *gitlabServer) listProjectHooks(ctx context.Context) ([]*gitlab.ProjectHook, error) {
+	repoInfo, err := git.GetProjectWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repoId := repoInfo["id"]
+	p := &gitlab.ListProjectHooksOptions{}
+	var projectHooks []*gitlab.ProjectHook
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		projectHooks, resp, err = git.api.Projects.ListProjectHooks(repoId, p, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projectHooks, nil
+}
+
+// FindProjectHookByURL find the project hook by url, return ErrHookNotFound when there is none
+func (git *gitlabServer) FindProjectHookByURL(url string) (*gitlab.ProjectHook, error) {
+	return git.FindProjectHookByURLWithContext(context.Background(), url)
+}
+
+// FindProjectHookByURLWithContext is like FindProjectHookByURL but binds the request to ctx
+func (git *gitlabServer) FindProjectHookByURLWithContext(ctx context.Context, url string) (*gitlab.ProjectHook, error) {
+	projectHooks, err := git.listProjectHooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range projectHooks {
+		if hook.URL == url {
+			return hook, nil
+		}
+	}
+	return nil, fmt.Errorf("url %s: %w", url, ErrHookNotFound)
+}
+
 // IsProjectHookExists if project hook exists return true, otherwise return false
 func (git *gitlabServer) IsProjectHookExists(url string) (string, error) {
-	projectHookSlice, err := git.ListProjectHook()
+	return git.IsProjectHookExistsWithContext(context.Background(), url)
+}
+
+// IsProjectHookExistsWithContext is like IsProjectHookExists but binds the request to ctx
+func (git *gitlabServer) IsProjectHookExistsWithContext(ctx context.Context, url string) (string, error) {
+	exists, err := git.ProjectHookExistsWithContext(ctx, url)
 	if err != nil {
 		return fmt.Sprintf("list project hook: <%v> error", git.ProjectName), err
 	}
+	if !exists {
+		return "", fmt.Errorf("url %s: %w", url, ErrHookNotFound)
+	}
+	return fmt.Sprintf("project %s hook already exists", git.ProjectName), nil
+}
+
+// ProjectHookExists report whether the project has a hook for url, err is only set on API failures
+func (git *gitlabServer) ProjectHookExists(url string) (bool, error) {
+	return git.ProjectHookExistsWithContext(context.Background(), url)
+}
+
+// ProjectHookExistsWithContext is like ProjectHookExists but binds the request to ctx
+func (git *gitlabServer) ProjectHookExistsWithContext(ctx context.Context, url string) (bool, error) {
+	projectHookSlice, err := git.ListProjectHookWithContext(ctx)
+	if err != nil {
+		return false, err
+	}
 	for _, m := range projectHookSlice {
-		fmt.Println(m["url"], url)
 		if m["url"].(string) == url {
-			return fmt.Sprintf("project %s hook already exists", git.ProjectName), nil
+			return true, nil
 		}
 	}
-	return "", errors.New("not found")
+	return false, nil
 }
 
-// CreateProjectHookByPush create a project's push hook
-func (git *gitlabServer) CreateProjectHookByPush(url, branch string, pushEvents, enableSSLVerification bool) (string, error) {
-	repoInfo, err := git.GetProject()
+// CreateProjectHook create a project hook with any combination of events and return its id,
+// it fails when a hook with the same url already exists
+func (git *gitlabServer) CreateProjectHook(opts *gitlab.AddProjectHookOptions) (int, error) {
+	return git.CreateProjectHookWithContext(context.Background(), opts)
+}
+
+// CreateProjectHookWithContext is like CreateProjectHook but binds the request to ctx
+func (git *gitlabServer) CreateProjectHookWithContext(ctx context.Context, opts *gitlab.AddProjectHookOptions) (int, error) {
+	if opts == nil || opts.URL == nil {
+		return 0, fmt.Errorf("add project hook: url is required: %w", ErrInvalidArgument)
+	}
+	if opts.Token == nil && git.HookToken != "" {
+		withToken := *opts
+		withToken.Token = gitlab.String(git.HookToken)
+		opts = &withToken
+	}
+	url := *opts.URL
+	if git.DryRun {
+		git.logger().Debugf("dry run: add project %s hook %s", git.ProjectName, url)
+		return 0, nil
+	}
+	repoInfo, err := git.GetProjectWithContext(ctx)
 	if err != nil {
-		return "", err
+		return 0, err
 	}
 	repoId := repoInfo["id"].(float64)
 
-	_, err = git.IsProjectHookExists(url)
+	_, err = git.IsProjectHookExistsWithContext(ctx, url)
 	if err == nil {
-		return "", errors.New(fmt.Sprintf("url: %s already exists", url))
+		return 0, fmt.Errorf("hook %s: %w", url, ErrHookExists)
+	}
+	if !errors.Is(err, ErrHookNotFound) {
+		return 0, err
+	}
+	var projectHooks *gitlab.ProjectHook
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		projectHooks, resp, err = git.api.Projects.AddProjectHook(int(repoId), opts, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return projectHooks.ID, nil
+}
+
+// createProjectHook create the hook and describe the result like the other hook methods
+func (git *gitlabServer) createProjectHook(ctx context.Context, p *gitlab.AddProjectHookOptions) (string, error) {
+	hookId, err := git.CreateProjectHookWithContext(ctx, p)
+	if err != nil {
+		return fmt.Sprintf("add project hook: <%v> error", git.ProjectName), err
 	}
+	return fmt.Sprintf("add project hook: <%v> ok, hook_id: %d", git.ProjectName, hookId), nil
+}
+
+// CreateProjectHookByPush create a project's push hook
+func (git *gitlabServer) CreateProjectHookByPush(url, branch string, pushEvents, enableSSLVerification bool) (string, error) {
+	return git.CreateProjectHookByPushWithContext(context.Background(), url, branch, pushEvents, enableSSLVerification)
+}
+
+// CreateProjectHookByPushWithContext is like CreateProjectHookByPush but binds the request to ctx
+func (git *gitlabServer) CreateProjectHookByPushWithContext(ctx context.Context, url, branch string, pushEvents, enableSSLVerification bool) (string, error) {
 	p := &gitlab.AddProjectHookOptions{
 		URL:                    &url,
 		PushEventsBranchFilter: &branch,
 		PushEvents:             &pushEvents,
 		EnableSSLVerification:  &enableSSLVerification,
 	}
-	projectHooks, _, err := git.Client.Projects.AddProjectHook(int(repoId), p)
-	if err != nil {
-		return fmt.Sprintf("add project hook: <%v> error", git.ProjectName), err
-	}
-	return fmt.Sprintf("add project hook: <%v> ok, hook_id: %d", git.ProjectName, projectHooks.ID), nil
+	return git.createProjectHook(ctx, p)
 }
 
 // CreateProjectHookByTag create a project's tag hook
 func (git *gitlabServer) CreateProjectHookByTag(url, branch string, tagPushEvents, enableSSLVerification bool) (string, error) {
-	repoInfo, err := git.GetProject()
+	return git.CreateProjectHookByTagWithContext(context.Background(), url, branch, tagPushEvents, enableSSLVerification)
+}
+
+// CreateProjectHookByTagWithContext is like CreateProjectHookByTag but binds the request to ctx
+func (git *gitlabServer) CreateProjectHookByTagWithContext(ctx context.Context, url, branch string, tagPushEvents, enableSSLVerification bool) (string, error) {
+	p := &gitlab.AddProjectHookOptions{
+		URL:                    &url,
+		PushEventsBranchFilter: &branch,
+		TagPushEvents:          &tagPushEvents,
+		EnableSSLVerification:  &enableSSLVerification,
+	}
+	return git.createProjectHook(ctx, p)
+}
+
+// CreateProjectHookByMergeRequest create a project's merge request hook
+func (git *gitlabServer) CreateProjectHookByMergeRequest(url string, mergeRequestsEvents, enableSSLVerification bool) (string, error) {
+	return git.CreateProjectHookByMergeRequestWithContext(context.Background(), url, mergeRequestsEvents, enableSSLVerification)
+}
+
+// CreateProjectHookByMergeRequestWithContext is like CreateProjectHookByMergeRequest but binds the request to ctx
+func (git *gitlabServer) CreateProjectHookByMergeRequestWithContext(ctx context.Context, url string, mergeRequestsEvents, enableSSLVerification bool) (string, error) {
+	p := &gitlab.AddProjectHookOptions{
+		URL:                   &url,
+		PushEvents:            gitlab.Bool(false),
+		MergeRequestsEvents:   &mergeRequestsEvents,
+		EnableSSLVerification: &enableSSLVerification,
+	}
+	return git.createProjectHook(ctx, p)
+}
+
+// CreateProjectHookByPipeline create a project's pipeline hook
+func (git *gitlabServer) CreateProjectHookByPipeline(url string, pipelineEvents, enableSSLVerification bool) (string, error) {
+	return git.CreateProjectHookByPipelineWithContext(context.Background(), url, pipelineEvents, enableSSLVerification)
+}
+
+// CreateProjectHookByPipelineWithContext is like CreateProjectHookByPipeline but binds the request to ctx
+func (git *gitlabServer) CreateProjectHookByPipelineWithContext(ctx context.Context, url string, pipelineEvents, enableSSLVerification bool) (string, error) {
+	p := &gitlab.AddProjectHookOptions{
+		URL:                   &url,
+		PushEvents:            gitlab.Bool(false),
+		PipelineEvents:        &pipelineEvents,
+		EnableSSLVerification: &enableSSLVerification,
+	}
+	return git.createProjectHook(ctx, p)
+}
+
+// GetProjectHookId find the id of the project hook by url
+func (git *gitlabServer) GetProjectHookId(url string) (int, error) {
+	return git.GetProjectHookIdWithContext(context.Background(), url)
+}
+
+// GetProjectHookIdWithContext is like GetProjectHookId but binds the request to ctx
+func (git *gitlabServer) GetProjectHookIdWithContext(ctx context.Context, url string) (int, error) {
+	hook, err := git.FindProjectHookByURLWithContext(ctx, url)
+	if err != nil {
+		return 0, err
+	}
+	return hook.ID, nil
+}
+
+// UpdateProjectHook edit a project's hook
+func (git *gitlabServer) UpdateProjectHook(hookId int, opts *gitlab.EditProjectHookOptions) (string, error) {
+	return git.UpdateProjectHookWithContext(context.Background(), hookId, opts)
+}
+
+// UpdateProjectHookWithContext is like UpdateProjectHook but binds the request to ctx
+func (git *gitlabServer) UpdateProjectHookWithContext(ctx context.Context, hookId int, opts *gitlab.EditProjectHookOptions) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Projects.EditProjectHook(int(projectId), hookId, opts, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("update project hook: <%v> error, hook_id: %d", git.ProjectName, hookId), err
+	}
+	return fmt.Sprintf("update project hook: <%v> ok, hook_id: %d", git.ProjectName, hookId), nil
+}
+
+// UpdateProjectHookByURL edit the project's hook matched by url
+func (git *gitlabServer) UpdateProjectHookByURL(url string, opts *gitlab.EditProjectHookOptions) (string, error) {
+	return git.UpdateProjectHookByURLWithContext(context.Background(), url, opts)
+}
+
+// UpdateProjectHookByURLWithContext is like UpdateProjectHookByURL but binds the request to ctx
+func (git *gitlabServer) UpdateProjectHookByURLWithContext(ctx context.Context, url string, opts *gitlab.EditProjectHookOptions) (string, error) {
+	hookId, err := git.GetProjectHookIdWithContext(ctx, url)
+	if err != nil {
+		return fmt.Sprintf("update project hook: <%v> error", git.ProjectName), err
+	}
+	return git.UpdateProjectHookWithContext(ctx, hookId, opts)
+}
+
+// DeleteProjectHook delete a project's hook
+func (git *gitlabServer) DeleteProjectHook(hookId int) (string, error) {
+	return git.DeleteProjectHookWithContext(context.Background(), hookId)
+}
+
+// DeleteProjectHookWithContext is like DeleteProjectHook but binds the request to ctx
+func (git *gitlabServer) DeleteProjectHookWithContext(ctx context.Context, hookId int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.Projects.DeleteProjectHook(int(projectId), hookId, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("delete project hook: <%v> error, hook_id: %d", git.ProjectName, hookId), err
+	}
+	return fmt.Sprintf("delete project hook: <%v> ok, hook_id: %d", git.ProjectName, hookId), nil
+}
+
+// listOptions return the first page options for a list request
+func (git *gitlabServer) listOptions() gitlab.ListOptions {
+	perPage := git.PerPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	return gitlab.ListOptions{Page: 1, PerPage: perPage}
+}
+
+// maxPages return the max pages a list request may walk
+func (git *gitlabServer) maxPages() int {
+	if git.MaxPages <= 0 {
+		return defaultMaxPages
+	}
+	return git.MaxPages
+}
+
+// respStatus return the http status of resp, falls back to err's message when there is no response
+func respStatus(resp *gitlab.Response, err error) string {
+	if resp == nil || resp.Response == nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+// convertToMaps convert go-gitlab objects to a slice of generic maps
+func convertToMaps(v interface{}) (data []map[string]interface{}, err error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(bytes, &data)
+	return
+}
+
+// convertToMap convert a go-gitlab object to a generic map
+func convertToMap(v interface{}) (data map[string]interface{}, err error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(bytes, &data)
+	return
+}
+
+// ListProject list all repo by group
+func (git *gitlabServer) ListProject() ([]map[string]interface{}, error) {
+	return git.ListProjectWithContext(context.Background())
+}
+
+// ListProjectWithContext is like ListProject but binds the request to ctx
+func (git *gitlabServer) ListProjectWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	return git.listProjects(ctx, ProjectFilter{})
+}
+
+// listProjects list the group projects matching filter
+func (git *gitlabServer) listProjects(ctx context.Context, filter ProjectFilter) ([]map[string]interface{}, error) {
+	gid, err := git.groupRef("list group projects")
+	if err != nil {
+		return nil, err
+	}
+	var data []map[string]interface{}
+	lp, filterOptions := git.groupProjectsOptions(filter)
+	var projectGroup []*gitlab.Project
+	keyset := git.keysetOptions()
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return data, fmt.Errorf("list group projects: exceeded max pages %d", git.maxPages())
+		}
+		var projects []*gitlab.Project
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			projects, resp, err = git.api.Groups.ListGroupProjects(gid, lp, append(append(optionFuncs, filterOptions...), keyset...)...)
+			return
+		})
+		if err != nil {
+			return data, err
+		}
+		projectGroup = append(projectGroup, projects...)
+		if git.Keyset != nil {
+			var ok bool
+			if keyset, ok = nextKeysetOptions(resp); !ok {
+				break
+			}
+			continue
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		lp.Page = resp.NextPage
+	}
+	bytes, err := json.Marshal(&projectGroup)
+	if err != nil {
+		return data, err
+	}
+	err = json.Unmarshal(bytes, &data)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// groupProjectsOptions return the group project listing options narrowed by filter,
+// along with the request options carrying what go-gitlab has no field for
+func (git *gitlabServer) groupProjectsOptions(filter ProjectFilter) (*gitlab.ListGroupProjectsOptions, []gitlab.RequestOptionFunc) {
+	simple := !git.FullProjectData
+	lp := &gitlab.ListGroupProjectsOptions{
+		ListOptions: git.listOptions(),
+		Simple:      &simple,
+	}
+	return lp, filter.apply(lp)
+}
+
+// SearchProjects search projects by name across the whole instance visible to the token,
+// each result carries path_with_namespace to tell same-named projects apart
+func (git *gitlabServer) SearchProjects(query string) ([]map[string]interface{}, error) {
+	return git.SearchProjectsWithContext(context.Background(), query)
+}
+
+// SearchProjectsWithContext is like SearchProjects but binds the request to ctx
+func (git *gitlabServer) SearchProjectsWithContext(ctx context.Context, query string) ([]map[string]interface{}, error) {
+	lp := &gitlab.ListProjectsOptions{
+		ListOptions: git.listOptions(),
+		Search:      gitlab.String(query),
+		Simple:      gitlab.Bool(true),
+	}
+	var projectSlice []*gitlab.Project
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("search projects: exceeded max pages %d", git.maxPages())
+		}
+		var projects []*gitlab.Project
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			projects, resp, err = git.api.Projects.ListProjects(lp, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		projectSlice = append(projectSlice, projects...)
+		if resp.NextPage == 0 {
+			break
+		}
+		lp.Page = resp.NextPage
+	}
+	return convertToMaps(projectSlice)
+}
+
+// GetProject get project info
+func (git *gitlabServer) GetProject() (map[string]interface{}, error) {
+	return git.GetProjectWithContext(context.Background())
+}
+
+// GetProjectWithContext is like GetProject but binds the request to ctx
+func (git *gitlabServer) GetProjectWithContext(ctx context.Context) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	repoSlice, err := git.listProjects(ctx, git.projectLookupFilter())
+	if err != nil {
+		return data, err
+	}
+	for _, project := range repoSlice {
+		if git.matchesProject(project) {
+			return project, nil
+		}
+	}
+	return data, fmt.Errorf("project %s: %w", git.ProjectName, ErrProjectNotFound)
+}
+
+// GetProjectById get project info by project id without listing the group
+func (git *gitlabServer) GetProjectById(projectId int) (map[string]interface{}, error) {
+	return git.GetProjectByIdWithContext(context.Background(), projectId)
+}
+
+// GetProjectByIdWithContext is like GetProjectById but binds the request to ctx
+func (git *gitlabServer) GetProjectByIdWithContext(ctx context.Context, projectId int) (map[string]interface{}, error) {
+	var project *gitlab.Project
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		project, resp, err = git.api.Projects.GetProject(projectId, &gitlab.GetProjectOptions{}, optionFuncs...)
+		return
+	})
 	if err != nil {
-		return "", err
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("project id %d: %w", projectId, ErrProjectNotFound)
+		}
+		return nil, err
 	}
-	repoId := repoInfo["id"].(float64)
+	return convertToMap(project)
+}
 
-	_, err = git.IsProjectHookExists(url)
-	if err == nil {
-		return "", errors.New(fmt.Sprintf("url: %s already exists", url))
-	}
-	p := &gitlab.AddProjectHookOptions{
-		URL:                    &url,
-		PushEventsBranchFilter: &branch,
-		TagPushEvents:          &tagPushEvents,
-		EnableSSLVerification:  &enableSSLVerification,
-	}
-	projectHooks, _, err := git.Client.Projects.AddProjectHook(int(repoId), p)
+// GetProjectByPath get project info by its path with namespace, e.g. team/sub/project,
+// unlike GetProject it is unambiguous across subgroups and does not list the group
+func (git *gitlabServer) GetProjectByPath(pathWithNamespace string) (map[string]interface{}, error) {
+	return git.GetProjectByPathWithContext(context.Background(), pathWithNamespace)
+}
+
+// GetProjectByPathWithContext is like GetProjectByPath but binds the request to ctx
+func (git *gitlabServer) GetProjectByPathWithContext(ctx context.Context, pathWithNamespace string) (map[string]interface{}, error) {
+	var project *gitlab.Project
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		// go-gitlab url-encodes the path, slashes included
+		project, resp, err = git.api.Projects.GetProject(pathWithNamespace, &gitlab.GetProjectOptions{}, optionFuncs...)
+		return
+	})
 	if err != nil {
-		return fmt.Sprintf("add project hook: <%v> error", git.ProjectName), err
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("project %s: %w", pathWithNamespace, ErrProjectNotFound)
+		}
+		return nil, err
 	}
-	return fmt.Sprintf("add project hook: <%v> ok, hook_id: %d", git.ProjectName, projectHooks.ID), nil
+	return convertToMap(project)
 }
 
-// ListProject list all repo by group
-func (git *gitlabServer) ListProject() ([]map[string]interface{}, error) {
-	var (
-		simple = true
-		data   []map[string]interface{}
-	)
-	lp := &gitlab.ListGroupProjectsOptions{
-		Simple: &simple,
-	}
-	projectGroup, _, err := git.Client.Groups.ListGroupProjects(*git.GroupId, lp)
+// GetProjectStatistics get the project statistics, e.g. repository_size, commit_count and storage_size,
+// they are only visible to tokens with at least reporter access
+func (git *gitlabServer) GetProjectStatistics() (map[string]interface{}, error) {
+	return git.GetProjectStatisticsWithContext(context.Background())
+}
+
+// GetProjectStatisticsWithContext is like GetProjectStatistics but binds the request to ctx
+func (git *gitlabServer) GetProjectStatisticsWithContext(ctx context.Context) (map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
 	if err != nil {
-		return data, err
+		return nil, err
 	}
-	bytes, err := json.Marshal(&projectGroup)
+	var project *gitlab.Project
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		project, resp, err = git.api.Projects.GetProject(int(projectId), &gitlab.GetProjectOptions{Statistics: gitlab.Bool(true)}, optionFuncs...)
+		return
+	})
 	if err != nil {
-		return data, err
+		if resp != nil && resp.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("project %s statistics: %w", git.ProjectName, ErrPermissionDenied)
+		}
+		return nil, err
 	}
-	err = json.Unmarshal(bytes, &data)
-	if err != nil {
-		return data, err
+	if project.Statistics == nil {
+		// GitLab silently omits the statistics below reporter access
+		return nil, fmt.Errorf("project %s statistics: %w", git.ProjectName, ErrPermissionDenied)
 	}
-	return data, nil
+	return convertToMap(project.Statistics)
 }
 
-// GetProject get project info
-func (git *gitlabServer) GetProject() (map[string]interface{}, error) {
-	data := make(map[string]interface{})
-	repoSlice, err := git.ListProject()
+// GetDefaultBranch get the project's default branch, e.g. main or master
+func (git *gitlabServer) GetDefaultBranch() (string, error) {
+	return git.GetDefaultBranchWithContext(context.Background())
+}
+
+// GetDefaultBranchWithContext is like GetDefaultBranch but binds the request to ctx
+func (git *gitlabServer) GetDefaultBranchWithContext(ctx context.Context) (string, error) {
+	project, err := git.GetProjectWithContext(ctx)
 	if err != nil {
-		return data, err
+		return "", err
 	}
-	for _, project := range repoSlice {
-		if project["name"] == git.ProjectName {
-			return project, nil
-		}
+	branch, _ := project["default_branch"].(string)
+	if branch == "" {
+		// empty repositories have no default branch yet
+		return "", fmt.Errorf("project %s has no default branch", git.ProjectName)
+	}
+	return branch, nil
+}
+
+// groupRef return the group id, or the group path when only GroupName is set, op names the
+// operation in the error
+func (git *gitlabServer) groupRef(op string) (interface{}, error) {
+	switch {
+	case git.GroupId != nil:
+		return *git.GroupId, nil
+	case git.GroupName != "":
+		return git.GroupName, nil
+	default:
+		return nil, fmt.Errorf("%s: neither group id nor group name is set: %w", op, ErrInvalidArgument)
 	}
-	return data, errors.New("not found")
 }
 
-// getProjectPath get project path
-func (git *gitlabServer) getProjectPath() string {
-	return fmt.Sprintf("%s/%s", git.GroupName, git.ProjectName)
+// getProjectPath get the project's path with namespace from the project lookup
+func (git *gitlabServer) getProjectPath(ctx context.Context) (string, error) {
+	// ProjectName is only a path segment when it is matched by path as is, a name like
+	// "My Service" or a nested group needs the path GitLab reports for the project
+	if git.GroupId == nil && git.ProjectMatch == MatchPath && !git.ProjectMatchIgnoreCase {
+		return fmt.Sprintf("%s/%s", git.GroupName, git.ProjectName), nil
+	}
+	project, err := git.lookupProject(ctx)
+	if err != nil {
+		return "", err
+	}
+	return project.path, nil
 }
 
 // GetProjectId if project exists return (projectId, true), otherwise return (0, false)
 func (git *gitlabServer) GetProjectId() (float64, error) {
-	repoSlice, err := git.ListProject()
+	return git.GetProjectIdWithContext(context.Background())
+}
+
+// GetProjectIdWithContext is like GetProjectId but binds the request to ctx
+func (git *gitlabServer) GetProjectIdWithContext(ctx context.Context) (float64, error) {
+	project, err := git.lookupProject(ctx)
 	if err != nil {
 		return 0, err
 	}
+	return project.id, nil
+}
+
+// lookupProject find the configured project in the group, cached for ProjectIdCacheTTL
+func (git *gitlabServer) lookupProject(ctx context.Context) (projectIdEntry, error) {
+	if err := requireArgs("get project id", "project name", git.ProjectName); err != nil {
+		return projectIdEntry{}, err
+	}
+	ttl := git.projectIdCacheTTL()
+	key := git.projectIdKey()
+	if ttl > 0 {
+		if project, ok := git.projectIds.get(key); ok {
+			return project, nil
+		}
+	}
+	repoSlice, err := git.listProjects(ctx, git.projectLookupFilter())
+	if err != nil {
+		return projectIdEntry{}, err
+	}
 	for _, project := range repoSlice {
-		if project["name"] == git.ProjectName {
-			return project["id"].(float64), nil
+		if git.matchesProject(project) {
+			id := project["id"].(float64)
+			path, _ := project["path_with_namespace"].(string)
+			if ttl > 0 {
+				git.projectIds.set(key, id, path, ttl)
+			}
+			return projectIdEntry{id: id, path: path}, nil
 		}
 	}
-	return 0, errors.New(fmt.Sprintf("project %s not exists", git.ProjectName))
+	return projectIdEntry{}, fmt.Errorf("project %s: %w", git.ProjectName, ErrProjectNotFound)
 }
 
 // IsProjectExists if repo exists return true, otherwise return false
 func (git *gitlabServer) IsProjectExists() (string, error) {
-	repoSlice, err := git.ListProject()
+	return git.IsProjectExistsWithContext(context.Background())
+}
+
+// IsProjectExistsWithContext is like IsProjectExists but binds the request to ctx
+func (git *gitlabServer) IsProjectExistsWithContext(ctx context.Context) (string, error) {
+	exists, err := git.ProjectExistsWithContext(ctx)
 	if err != nil {
 		return "", err
 	}
-	for _, project := range repoSlice {
-		if project["name"] == git.ProjectName {
-			return fmt.Sprintf("project name %s already exists", git.ProjectName), nil
-		}
+	if !exists {
+		return "", fmt.Errorf("project %s: %w", git.ProjectName, ErrProjectNotFound)
 	}
-	return "", errors.New("not found")
+	return fmt.Sprintf("project name %s already exists", git.ProjectName), nil
 }
 
-// ListProjectCommit Get a list of repository commits in a project.
-func (git *gitlabServer) ListProjectCommit(branch string) (data []map[string]interface{}, err error) {
-	projectId, err := git.GetProjectId()
+// ProjectExists report whether the project exists in the group, err is only set on API failures
+func (git *gitlabServer) ProjectExists() (bool, error) {
+	return git.ProjectExistsWithContext(context.Background())
+}
+
+// ProjectExistsWithContext is like ProjectExists but binds the request to ctx
+func (git *gitlabServer) ProjectExistsWithContext(ctx context.Context) (bool, error) {
+	repoSlice, err := git.listProjects(ctx, git.projectLookupFilter())
 	if err != nil {
-		return
+		return false, err
 	}
-	options := &gitlab.ListCommitsOptions{
-		RefName: &branch,
+	for _, project := range repoSlice {
+		if git.matchesProject(project) {
+			return true, nil
+		}
 	}
+	return false, nil
+}
+
+// ListProjectCommit Get a list of repository commits in a project.
+func (git *gitlabServer) ListProjectCommit(branch string, opts ...CommitOption) (data []map[string]interface{}, err error) {
+	return git.ListProjectCommitWithContext(context.Background(), branch, opts...)
+}
 
-	commitSlice, _, err := git.Client.Commits.ListCommits(int(projectId), options)
+// ListProjectCommitWithContext is like ListProjectCommit but binds the request to ctx
+func (git *gitlabServer) ListProjectCommitWithContext(ctx context.Context, branch string, opts ...CommitOption) (data []map[string]interface{}, err error) {
+	commitSlice, err := git.listCommits(ctx, branch, opts)
 	if err != nil {
 		return
 	}
@@ -234,16 +1092,13 @@ func (git *gitlabServer) ListProjectCommit(branch string) (data []map[string]int
 }
 
 // ListProjectCommitFormat Get a list of repository commits in a project.
-func (git *gitlabServer) ListProjectCommitFormat(branch string) (data []map[string]interface{}, err error) {
-	projectId, err := git.GetProjectId()
-	if err != nil {
-		return
-	}
-	options := &gitlab.ListCommitsOptions{
-		RefName: &branch,
-	}
+func (git *gitlabServer) ListProjectCommitFormat(branch string, opts ...CommitOption) (data []map[string]interface{}, err error) {
+	return git.ListProjectCommitFormatWithContext(context.Background(), branch, opts...)
+}
 
-	commitSlice, _, err := git.Client.Commits.ListCommits(int(projectId), options)
+// ListProjectCommitFormatWithContext is like ListProjectCommitFormat but binds the request to ctx
+func (git *gitlabServer) ListProjectCommitFormatWithContext(ctx context.Context, branch string, opts ...CommitOption) (data []map[string]interface{}, err error) {
+	commitSlice, err := git.listCommits(ctx, branch, opts)
 	if err != nil {
 		return
 	}
@@ -252,120 +1107,543 @@ func (git *gitlabServer) ListProjectCommitFormat(branch string) (data []map[stri
 		opt["commit_id"] = commit.ShortID
 		opt["commit_message"] = commit.Title
 		opt["commit_author"] = commit.AuthorName
+		opt["commit_author_email"] = commit.AuthorEmail
+		opt["commit_date"] = commit.CommittedDate
 		data = append(data, opt)
 	}
 
 	return
 }
 
+// listCommits list the commits of branch, walking all pages from the requested one
+func (git *gitlabServer) listCommits(ctx context.Context, branch string, opts []CommitOption) ([]*gitlab.Commit, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListCommitsOptions{
+		ListOptions: git.listOptions(),
+		RefName:     &branch,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	var commitSlice []*gitlab.Commit
+	keyset := git.keysetOptions()
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list commits: exceeded max pages %d", git.maxPages())
+		}
+		var commits []*gitlab.Commit
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			commits, resp, err = git.api.Commits.ListCommits(int(projectId), options, append(optionFuncs, keyset...)...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		commitSlice = append(commitSlice, commits...)
+		if git.Keyset != nil {
+			var ok bool
+			if keyset, ok = nextKeysetOptions(resp); !ok {
+				break
+			}
+			continue
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return commitSlice, nil
+}
+
 // RollbackProjectCommit Reverts a commit in a given branch
 func (git *gitlabServer) RollbackProjectCommit(branch, commitId string) (string, error) {
-	projectId, err := git.GetProjectId()
+	return git.RollbackProjectCommitWithContext(context.Background(), branch, commitId)
+}
+
+// RollbackProjectCommitWithContext is like RollbackProjectCommit but binds the request to ctx
+func (git *gitlabServer) RollbackProjectCommitWithContext(ctx context.Context, branch, commitId string) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
 	if err != nil {
 		return "get project id error", err
 	}
 	options := &gitlab.RevertCommitOptions{
 		Branch: &branch,
 	}
-	commit, _, err := git.Client.Commits.RevertCommit(int(projectId), commitId, options)
+	var commit *gitlab.Commit
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		commit, resp, err = git.api.Commits.RevertCommit(int(projectId), commitId, options, optionFuncs...)
+		return
+	})
 	if err != nil {
 		return fmt.Sprintf("rollback commit %s/%s error", branch, commitId), err
 	}
-	fmt.Printf("commit: %v\n", commit)
+	git.logger().Debugf("rollback commit %s/%s, revert commit: %s", branch, commitId, commit.ID)
 	return fmt.Sprintf("rollback commit %s/%s ok", branch, commitId), nil
 }
 
-// CreateFile Create a new repository file
+// CreateFile Create a new repository file, an empty branch means the default branch
 func (git *gitlabServer) CreateFile(branch, filename, fileContent, commitMessage string) (string, error) {
+	return git.CreateFileWithContext(context.Background(), branch, filename, fileContent, commitMessage)
+}
+
+// CreateFileWithContext is like CreateFile but binds the request to ctx
+func (git *gitlabServer) CreateFileWithContext(ctx context.Context, branch, filename, fileContent, commitMessage string) (string, error) {
+	if err := requireArgs("create file", "filename", filename, "commitMessage", commitMessage); err != nil {
+		return fmt.Sprintf("create file: <%s> error", filename), err
+	}
+	if branch == "" {
+		defaultBranch, err := git.GetDefaultBranchWithContext(ctx)
+		if err != nil {
+			return "get default branch error", err
+		}
+		branch = defaultBranch
+	}
+	if git.DryRun {
+		git.logger().Debugf("dry run: create file %s on branch %s", filename, branch)
+		return fmt.Sprintf("create file: <%s> ok, dry run", filename), nil
+	}
 	cf := &gitlab.CreateFileOptions{
 		Branch:        gitlab.String(branch),
 		Content:       gitlab.String(fileContent),
-		CommitMessage: gitlab.String(commitMessage),
+		CommitMessage: gitlab.String(git.commitMessage(commitMessage)),
+	}
+	cf.AuthorName, cf.AuthorEmail = git.author()
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return "get project path error", err
 	}
-	_, resp, err := git.Client.RepositoryFiles.CreateFile(git.getProjectPath(), filename, cf)
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.RepositoryFiles.CreateFile(projectPath, filename, cf, optionFuncs...)
+		return
+	})
 	if err != nil {
-		return fmt.Sprintf("create file: <%s> error, err: %v\n", filename, resp.Response.Status), err
+		return fmt.Sprintf("create file: <%s> error, err: %v\n", filename, respStatus(resp, err)), err
 	}
 	return fmt.Sprintf("create file: <%s> ok", filename), err
 }
 
-// CreateFileInter Create a new repository file
+// CreateFileInter Create a new repository file, rendered as JSON for .json files when f implements RenderJSON
 func (git *gitlabServer) CreateFileInter(branch, filename string, f fileContentInter, commitMessage string) (string, error) {
-	bytes, err := f.RenderYaml()
+	return git.CreateFileInterWithContext(context.Background(), branch, filename, f, commitMessage)
+}
+
+// CreateFileInterWithContext is like CreateFileInter but binds the request to ctx
+func (git *gitlabServer) CreateFileInterWithContext(ctx context.Context, branch, filename string, f fileContentInter, commitMessage string) (string, error) {
+	bytes, err := renderFileContent(filename, f)
 	if err != nil {
-		return "", errors.New(fmt.Sprintf("renderYaml interface err: %v", err))
+		return "", err
 	}
-	cf := &gitlab.CreateFileOptions{
-		Branch:        gitlab.String(branch),
-		Content:       gitlab.String(string(bytes)),
-		CommitMessage: gitlab.String(commitMessage),
+	return git.CreateFileWithContext(ctx, branch, filename, string(bytes), commitMessage)
+}
+
+// CreateFileInterJSON Create a new repository file rendered as JSON
+func (git *gitlabServer) CreateFileInterJSON(branch, filename string, f jsonFileContentInter, commitMessage string) (string, error) {
+	return git.CreateFileInterJSONWithContext(context.Background(), branch, filename, f, commitMessage)
+}
+
+// CreateFileInterJSONWithContext is like CreateFileInterJSON but binds the request to ctx
+func (git *gitlabServer) CreateFileInterJSONWithContext(ctx context.Context, branch, filename string, f jsonFileContentInter, commitMessage string) (string, error) {
+	bytes, err := f.RenderJSON()
+	if err != nil {
+		return "", fmt.Errorf("renderJSON interface err: %v", err)
+	}
+	return git.CreateFileWithContext(ctx, branch, filename, string(bytes), commitMessage)
+}
+
+// renderFileContent render f as JSON when the filename has a .json extension and f implements RenderJSON, otherwise as YAML
+func renderFileContent(filename string, f fileContentInter) ([]byte, error) {
+	if j, ok := f.(jsonFileContentInter); ok && strings.EqualFold(path.Ext(filename), ".json") {
+		bytes, err := j.RenderJSON()
+		if err != nil {
+			return nil, fmt.Errorf("renderJSON interface err: %v", err)
+		}
+		return bytes, nil
 	}
-	_, resp, err := git.Client.RepositoryFiles.CreateFile(git.getProjectPath(), filename, cf)
+	bytes, err := f.RenderYaml()
 	if err != nil {
-		return fmt.Sprintf("create file: <%s> error, err: %v\n", filename, resp.Response.Status), err
+		return nil, errors.New(fmt.Sprintf("renderYaml interface err: %v", err))
 	}
-	return fmt.Sprintf("create file: <%s> ok", filename), err
+	return bytes, nil
 }
 
 // UpdateFileInter Update a repository file
 func (git *gitlabServer) UpdateFileInter(branch, filename string, f fileContentInter, commitMessage string) (string, error) {
-	bytes, err := f.RenderYaml()
+	return git.UpdateFileInterWithContext(context.Background(), branch, filename, f, commitMessage)
+}
+
+// UpdateFileInterWithContext is like UpdateFileInter but binds the request to ctx
+func (git *gitlabServer) UpdateFileInterWithContext(ctx context.Context, branch, filename string, f fileContentInter, commitMessage string) (string, error) {
+	bytes, err := renderFileContent(filename, f)
 	if err != nil {
-		return "", errors.New(fmt.Sprintf("renderYaml interface err: %v", err))
+		return "", err
+	}
+	return git.UpdateFileWithContext(ctx, branch, filename, string(bytes), commitMessage)
+}
+
+// UpdateFile Update a repository file, an empty branch means the default branch
+func (git *gitlabServer) UpdateFile(branch, filename, fileContent, commitMessage string) (string, error) {
+	return git.UpdateFileWithContext(context.Background(), branch, filename, fileContent, commitMessage)
+}
+
+// UpdateFileWithContext is like UpdateFile but binds the request to ctx
+func (git *gitlabServer) UpdateFileWithContext(ctx context.Context, branch, filename, fileContent, commitMessage string) (string, error) {
+	if err := requireArgs("update file", "filename", filename, "commitMessage", commitMessage); err != nil {
+		return fmt.Sprintf("update file: <%s> error", filename), err
+	}
+	if branch == "" {
+		defaultBranch, err := git.GetDefaultBranchWithContext(ctx)
+		if err != nil {
+			return "get default branch error", err
+		}
+		branch = defaultBranch
+	}
+	if git.DryRun {
+		git.logger().Debugf("dry run: update file %s on branch %s", filename, branch)
+		return fmt.Sprintf("update file: <%s> ok, dry run", filename), nil
 	}
 	uf := &gitlab.UpdateFileOptions{
 		Branch:        gitlab.String(branch),
-		Content:       gitlab.String(string(bytes)),
-		CommitMessage: gitlab.String(commitMessage),
+		Content:       gitlab.String(fileContent),
+		CommitMessage: gitlab.String(git.commitMessage(commitMessage)),
 	}
-	_, resp, err := git.Client.RepositoryFiles.UpdateFile(git.getProjectPath(), filename, uf)
+	uf.AuthorName, uf.AuthorEmail = git.author()
+	projectPath, err := git.getProjectPath(ctx)
 	if err != nil {
-		return fmt.Sprintf("update file: <%s> error, err: %v\n", filename, resp.Response.Status), err
+		return "get project path error", err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.RepositoryFiles.UpdateFile(projectPath, filename, uf, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("update file: <%s> error, err: %v\n", filename, respStatus(resp, err)), err
 	}
 	return fmt.Sprintf("update file: <%s> ok", filename), err
 }
 
-// UpdateFile Update a repository file
-func (git *gitlabServer) UpdateFile(branch, filename, fileContent, commitMessage string) (string, error) {
-	uf := &gitlab.UpdateFileOptions{
+// CreateOrUpdateFile Create the file if it is missing, otherwise update it
+func (git *gitlabServer) CreateOrUpdateFile(branch, filename, fileContent, commitMessage string) (string, error) {
+	return git.CreateOrUpdateFileWithContext(context.Background(), branch, filename, fileContent, commitMessage)
+}
+
+// CreateOrUpdateFileWithContext is like CreateOrUpdateFile but binds the request to ctx
+
+// CreateOrUpdateFileWithContext is like CreateOrUpdateFile but binds the request to ctx
+func (git *gitlabServer) CreateOrUpdateFileWithContext(ctx context.Context, branch, filename, fileContent, commitMessage string) (string, error) {
+	if branch == "" {
+		defaultBranch, err := git.GetDefaultBranchWithContext(ctx)
+		if err != nil {
+			return "get default branch error", err
+		}
+		branch = defaultBranch
+	}
+	exists, err := git.FileExistsWithContext(ctx, branch, filename)
+	if err != nil {
+		return fmt.Sprintf("get file: <%s> error, err: %v\n", filename, err), err
+	}
+	if exists {
+		return git.UpdateFileWithContext(ctx, branch, filename, fileContent, commitMessage)
+	}
+	msg, err := git.CreateFileWithContext(ctx, branch, filename, fileContent, commitMessage)
+	if isFileAlreadyExists(err) {
+		// the file was created between the check and the create
+		return git.UpdateFileWithContext(ctx, branch, filename, fileContent, commitMessage)
+	}
+	return msg, err
+}
+
+// CreateFileIfAbsent Create the file unless it already exists, created is false with a nil err when it does
+func (git *gitlabServer) CreateFileIfAbsent(branch, filename, fileContent, commitMessage string) (bool, error) {
+	return git.CreateFileIfAbsentWithContext(context.Background(), branch, filename, fileContent, commitMessage)
+}
+
+// CreateFileIfAbsentWithContext is like CreateFileIfAbsent but binds the request to ctx
+func (git *gitlabServer) CreateFileIfAbsentWithContext(ctx context.Context, branch, filename, fileContent, commitMessage string) (bool, error) {
+	_, err := git.CreateFileWithContext(ctx, branch, filename, fileContent, commitMessage)
+	if err != nil {
+		if isFileAlreadyExists(err) {
+			git.logger().Debugf("file %s already exists on branch %s, skip create", filename, branch)
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// isFileAlreadyExists report whether err is GitLab refusing to create an existing file
+func isFileAlreadyExists(err error) bool {
+	var errResp *gitlab.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
+	return errResp.Response.StatusCode == http.StatusBadRequest && strings.Contains(errResp.Message, "already exists")
+}
+
+// DeleteFile Delete a repository file
+func (git *gitlabServer) DeleteFile(branch, filename, commitMessage string) (string, error) {
+	return git.DeleteFileWithContext(context.Background(), branch, filename, commitMessage)
+}
+
+// DeleteFileWithContext is like DeleteFile but binds the request to ctx
+func (git *gitlabServer) DeleteFileWithContext(ctx context.Context, branch, filename, commitMessage string) (string, error) {
+	if err := requireArgs("delete file", "branch", branch, "filename", filename, "commitMessage", commitMessage); err != nil {
+		return fmt.Sprintf("delete file: <%s> error", filename), err
+	}
+	if git.DryRun {
+		git.logger().Debugf("dry run: delete file %s on branch %s", filename, branch)
+		return fmt.Sprintf("delete file: <%s> ok, dry run", filename), nil
+	}
+	df := &gitlab.DeleteFileOptions{
 		Branch:        gitlab.String(branch),
-		Content:       gitlab.String(fileContent),
-		CommitMessage: gitlab.String(commitMessage),
+		CommitMessage: gitlab.String(git.commitMessage(commitMessage)),
 	}
-	_, resp, err := git.Client.RepositoryFiles.UpdateFile(git.getProjectPath(), filename, uf)
+	df.AuthorName, df.AuthorEmail = git.author()
+	projectPath, err := git.getProjectPath(ctx)
 	if err != nil {
-		return fmt.Sprintf("update file: <%s> error, err: %v\n", filename, resp.Response.Status), err
+		return "get project path error", err
 	}
-	return fmt.Sprintf("update file: <%s> ok", filename), err
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.RepositoryFiles.DeleteFile(projectPath, filename, df, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Sprintf("delete file: <%s> error", filename), fmt.Errorf("file %s on branch %s: %w", filename, branch, ErrFileNotFound)
+		}
+		return fmt.Sprintf("delete file: <%s> error", filename), err
+	}
+	return fmt.Sprintf("delete file: <%s> ok", filename), nil
 }
 
 // GetRawFile get a file content
 func (git *gitlabServer) GetRawFile(branch, filename string) (string, error) {
+	return git.GetRawFileWithContext(context.Background(), branch, filename)
+}
+
+// GetRawFileWithContext is like GetRawFile but binds the request to ctx
+func (git *gitlabServer) GetRawFileWithContext(ctx context.Context, branch, filename string) (string, error) {
+	if err := requireArgs("get file", "branch", branch, "filename", filename); err != nil {
+		return fmt.Sprintf("get file: <%s> error", filename), err
+	}
 	gf := &gitlab.GetRawFileOptions{
 		Ref: gitlab.String(branch),
 	}
-	body, resp, err := git.Client.RepositoryFiles.GetRawFile(git.getProjectPath(), filename, gf)
+	key, cached, ok := git.lookupFileCache(ctx, branch, filename)
+	if ok {
+		return string(cached), nil
+	}
+	var body []byte
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return "get project path error", err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		body, resp, err = git.api.RepositoryFiles.GetRawFile(projectPath, filename, gf, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			err = fmt.Errorf("file %s on branch %s: %w", filename, branch, ErrFileNotFound)
+		}
+		return fmt.Sprintf("get file: <%s> error, err: %v\n", filename, respStatus(resp, err)), err
+	}
+	git.storeFileCache(key, resp, body)
+	return string(body), nil
+}
+
+// GetRawFileAtRef get a file content at ref, which may be a commit sha, a tag or a branch
+func (git *gitlabServer) GetRawFileAtRef(ref, filename string) (string, error) {
+	return git.GetRawFileAtRefWithContext(context.Background(), ref, filename)
+}
+
+// GetRawFileAtRefWithContext is like GetRawFileAtRef but binds the request to ctx
+func (git *gitlabServer) GetRawFileAtRefWithContext(ctx context.Context, ref, filename string) (string, error) {
+	body, err := git.GetRawFileBytesWithContext(ctx, ref, filename)
 	if err != nil {
-		return fmt.Sprintf("get file: <%s> error, err: %v\n", filename, resp.Response.Status), err
+		return "", err
 	}
 	return string(body), nil
 }
 
+// GetRawFileBytes get a file content as bytes at ref, a branch, tag or commit sha, on failure
+// the content is nil, unlike GetRawFile no message is ever returned in its place
+func (git *gitlabServer) GetRawFileBytes(ref, filename string) ([]byte, error) {
+	return git.GetRawFileBytesWithContext(context.Background(), ref, filename)
+}
+
+// GetRawFileBytesWithContext is like GetRawFileBytes but binds the request to ctx
+func (git *gitlabServer) GetRawFileBytesWithContext(ctx context.Context, ref, filename string) ([]byte, error) {
+	if err := requireArgs("get file", "ref", ref, "filename", filename); err != nil {
+		return nil, err
+	}
+	gf := &gitlab.GetRawFileOptions{
+		Ref: gitlab.String(ref),
+	}
+	key, cached, ok := git.lookupFileCache(ctx, ref, filename)
+	if ok {
+		// hand out a copy so callers cannot alter the cached content
+		return append([]byte(nil), cached...), nil
+	}
+	var body []byte
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		body, resp, err = git.api.RepositoryFiles.GetRawFile(projectPath, filename, gf, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("file %s on ref %s: %w", filename, ref, ErrFileNotFound)
+		}
+		return nil, err
+	}
+	git.storeFileCache(key, resp, body)
+	return body, nil
+}
+
 // IsFileExists if file exists return true, otherwise return false
 func (git *gitlabServer) IsFileExists(branch, filename string) bool {
+	return git.IsFileExistsWithContext(context.Background(), branch, filename)
+}
+
+// IsFileExistsWithContext is like IsFileExists but binds the request to ctx
+func (git *gitlabServer) IsFileExistsWithContext(ctx context.Context, branch, filename string) bool {
+	exists, _ := git.FileExistsWithContext(ctx, branch, filename)
+	return exists
+}
+
+// FileExists report whether the file exists on branch, err is only set on API failures
+func (git *gitlabServer) FileExists(branch, filename string) (bool, error) {
+	return git.FileExistsWithContext(context.Background(), branch, filename)
+}
+
+// FileExistsWithContext is like FileExists but binds the request to ctx
+func (git *gitlabServer) FileExistsWithContext(ctx context.Context, branch, filename string) (bool, error) {
+	if err := requireArgs("file exists", "branch", branch, "filename", filename); err != nil {
+		return false, err
+	}
 	gf := &gitlab.GetFileOptions{
 		Ref: gitlab.String(branch),
 	}
-	_, _, err := git.Client.RepositoryFiles.GetFile(git.getProjectPath(), filename, gf)
+	projectPath, err := git.getProjectPath(ctx)
 	if err != nil {
-		return false
+		return false, err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.RepositoryFiles.GetFile(projectPath, filename, gf, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetFileMetadata get a file's metadata such as blob_id, commit_id, size and content_sha256
+func (git *gitlabServer) GetFileMetadata(branch, filename string) (map[string]interface{}, error) {
+	return git.GetFileMetadataWithContext(context.Background(), branch, filename)
+}
+
+// GetFileMetadataWithContext is like GetFileMetadata but binds the request to ctx
+func (git *gitlabServer) GetFileMetadataWithContext(ctx context.Context, branch, filename string) (map[string]interface{}, error) {
+	if err := requireArgs("get file metadata", "branch", branch, "filename", filename); err != nil {
+		return nil, err
+	}
+	gf := &gitlab.GetFileOptions{
+		Ref: gitlab.String(branch),
+	}
+	var file *gitlab.File
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		file, resp, err = git.api.RepositoryFiles.GetFile(projectPath, filename, gf, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("file %s on ref %s: %w", filename, branch, ErrFileNotFound)
+		}
+		return nil, err
+	}
+	data, err := convertToMap(file)
+	if err != nil {
+		return nil, err
+	}
+	delete(data, "content")
+	return data, nil
+}
+
+// GetFileDecoded get a file's content together with its metadata in one request, the
+// base64 content GitLab returns is decoded and left out of the metadata
+func (git *gitlabServer) GetFileDecoded(branch, filename string) (string, map[string]interface{}, error) {
+	return git.GetFileDecodedWithContext(context.Background(), branch, filename)
+}
+
+// GetFileDecodedWithContext is like GetFileDecoded but binds the request to ctx
+func (git *gitlabServer) GetFileDecodedWithContext(ctx context.Context, branch, filename string) (string, map[string]interface{}, error) {
+	if err := requireArgs("get file", "branch", branch, "filename", filename); err != nil {
+		return "", nil, err
+	}
+	gf := &gitlab.GetFileOptions{
+		Ref: gitlab.String(branch),
 	}
-	return true
+	var file *gitlab.File
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		file, resp, err = git.api.RepositoryFiles.GetFile(projectPath, filename, gf, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", nil, fmt.Errorf("file %s on ref %s: %w", filename, branch, ErrFileNotFound)
+		}
+		return "", nil, err
+	}
+	content := file.Content
+	if file.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return "", nil, fmt.Errorf("decode file %s on ref %s: %w", filename, branch, err)
+		}
+		content = string(decoded)
+	}
+	data, err := convertToMap(file)
+	if err != nil {
+		return "", nil, err
+	}
+	delete(data, "content")
+	return content, data, nil
 }
 
 // CreateTag create a new tag
 func (git *gitlabServer) CreateTag(branch, tagName, message string) error {
-	projectId, err := git.GetProjectId()
+	return git.CreateTagWithContext(context.Background(), branch, tagName, message)
+}
+
+// CreateTagWithContext is like CreateTag but binds the request to ctx
+func (git *gitlabServer) CreateTagWithContext(ctx context.Context, branch, tagName, message string) error {
+	if err := requireArgs("create tag", "branch", branch, "tagName", tagName); err != nil {
+		return err
+	}
+	if git.DryRun {
+		git.logger().Debugf("dry run: create tag %s on %s", tagName, branch)
+		return nil
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -375,10 +1653,14 @@ func (git *gitlabServer) CreateTag(branch, tagName, message string) error {
 		Message: &message,
 	}
 
-	tag, _, err := git.Client.Tags.CreateTag(int(projectId), options)
+	var tag *gitlab.Tag
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		tag, resp, err = git.api.Tags.CreateTag(int(projectId), options, optionFuncs...)
+		return
+	})
 	if err != nil {
 		return err
 	}
-	fmt.Printf("tag: %v", tag)
+	git.logger().Debugf("create tag %s on %s, target: %s", tag.Name, branch, tag.Target)
 	return nil
 }
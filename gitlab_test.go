@@ -0,0 +1,70 @@
+package git
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBaseURLWithSubpath(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.SplitN(r.RequestURI, "?", 2)[0]
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/gitlab/api/v4/":
+			// go-gitlab probes the base url for rate limits when the client is created
+			fmt.Fprint(w, `{}`)
+		case path == "/gitlab/api/v4/groups/1/projects":
+			fmt.Fprint(w, `[{"id":7,"name":"project","path":"project","path_with_namespace":"group/project"}]`)
+		case strings.HasSuffix(path, "/raw"):
+			mu.Lock()
+			paths = append(paths, path)
+			mu.Unlock()
+			fmt.Fprint(w, "content")
+		case r.Method == http.MethodPost:
+			mu.Lock()
+			paths = append(paths, path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"file_path":"dir/app.yaml","branch":"main"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	server, err := NewGitlabServer("token", srv.URL+"/gitlab/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := server.WithGroup(1, "group").WithProject("project")
+
+	content, err := client.GetRawFile("main", "dir/app.yaml")
+	if err != nil {
+		t.Fatalf("GetRawFile: %v", err)
+	}
+	if content != "content" {
+		t.Errorf("GetRawFile = %q, want %q", content, "content")
+	}
+	if _, err := client.CreateFile("main", "dir/app.yaml", "content", "add app"); err != nil {
+		t.Fatalf("CreateFile: %v", err)
+	}
+
+	const prefix = "/gitlab/api/v4/projects/group%2Fproject/repository/files/"
+	if len(paths) != 2 {
+		t.Fatalf("got %d file requests, want 2: %v", len(paths), paths)
+	}
+	for _, path := range paths {
+		if !strings.HasPrefix(path, prefix) {
+			t.Errorf("request path %s does not start with %s", path, prefix)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ResolveGroup return a copy of the server with GroupId resolved from GroupName, e.g. team/sub
+func (git *gitlabServer) ResolveGroup() (GitClient, error) {
+	return git.ResolveGroupWithContext(context.Background())
+}
+
+// ResolveGroupWithContext is like ResolveGroup but binds the request to ctx
+func (git *gitlabServer) ResolveGroupWithContext(ctx context.Context) (GitClient, error) {
+	var gid interface{}
+	switch {
+	case git.GroupId != nil:
+		gid = *git.GroupId
+	case git.GroupName != "":
+		gid = git.GroupName
+	default:
+		return nil, fmt.Errorf("resolve group: neither GroupId nor GroupName is set: %w", ErrInvalidArgument)
+	}
+	options := &gitlab.GetGroupOptions{
+		WithProjects: gitlab.Bool(false),
+	}
+	var group *gitlab.Group
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		group, resp, err = git.api.Groups.GetGroup(gid, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("group %v: %w", gid, ErrGroupNotFound)
+		}
+		return nil, err
+	}
+	return git.withGroup(group.ID, git.GroupName), nil
+}
+
+// ResolveGroupId resolve a group path, e.g. team/sub, to its id
+func (git *gitlabServer) ResolveGroupId(groupPath string) (int, error) {
+	return git.ResolveGroupIdWithContext(context.Background(), groupPath)
+}
+
+// ResolveGroupIdWithContext is like ResolveGroupId but binds the request to ctx
+func (git *gitlabServer) ResolveGroupIdWithContext(ctx context.Context, groupPath string) (int, error) {
+	options := &gitlab.GetGroupOptions{
+		WithProjects: gitlab.Bool(false),
+	}
+	var group *gitlab.Group
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		group, resp, err = git.api.Groups.GetGroup(groupPath, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return 0, fmt.Errorf("group %s: %w", groupPath, ErrGroupNotFound)
+		}
+		return 0, err
+	}
+	return group.ID, nil
+}
+
+// CreateGroup create a group, or a subgroup of parentId when it is not nil, and return its id
+func (git *gitlabServer) CreateGroup(name, path string, parentId *int) (int, error) {
+	return git.CreateGroupWithContext(context.Background(), name, path, parentId)
+}
+
+// CreateGroupWithContext is like CreateGroup but binds the request to ctx
+func (git *gitlabServer) CreateGroupWithContext(ctx context.Context, name, path string, parentId *int) (int, error) {
+	options := &gitlab.CreateGroupOptions{
+		Name:     gitlab.String(name),
+		Path:     gitlab.String(path),
+		ParentID: parentId,
+	}
+	var group *gitlab.Group
+	_, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		group, resp, err = git.api.Groups.CreateGroup(options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return group.ID, nil
+}
+
+// ListGroupHooks list the hooks of the configured group
+func (git *gitlabServer) ListGroupHooks() ([]map[string]interface{}, error) {
+	return git.ListGroupHooksWithContext(context.Background())
+}
+
+// ListGroupHooksWithContext is like ListGroupHooks but binds the request to ctx
+func (git *gitlabServer) ListGroupHooksWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	gid, err := git.groupRef("list group hooks")
+	if err != nil {
+		return nil, err
+	}
+	options := gitlab.ListGroupHooksOptions(git.listOptions())
+	var hookSlice []*gitlab.GroupHook
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list group hooks: exceeded max pages %d", git.maxPages())
+		}
+		var hooks []*gitlab.GroupHook
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			hooks, resp, err = git.api.Groups.ListGroupHooks(gid, &options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, git.groupFeatureError(ctx, "list group hooks", gid, resp, err)
+		}
+		hookSlice = append(hookSlice, hooks...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(hookSlice)
+}
+
+// CreateGroupHook create a hook on the configured group covering all its current and future
+// projects and return its id, HookToken is used when opts carries no token
+func (git *gitlabServer) CreateGroupHook(opts *gitlab.AddGroupHookOptions) (int, error) {
+	return git.CreateGroupHookWithContext(context.Background(), opts)
+}
+
+// CreateGroupHookWithContext is like CreateGroupHook but binds the request to ctx
+func (git *gitlabServer) CreateGroupHookWithContext(ctx context.Context, opts *gitlab.AddGroupHookOptions) (int, error) {
+	if opts == nil || opts.URL == nil {
+		return 0, fmt.Errorf("add group hook: url is required: %w", ErrInvalidArgument)
+	}
+	gid, err := git.groupRef("add group hook")
+	if err != nil {
+		return 0, err
+	}
+	if opts.Token == nil && git.HookToken != "" {
+		withToken := *opts
+		withToken.Token = gitlab.String(git.HookToken)
+		opts = &withToken
+	}
+	if git.DryRun {
+		git.logger().Debugf("dry run: add group %v hook %s", gid, *opts.URL)
+		return 0, nil
+	}
+	var hook *gitlab.GroupHook
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		hook, resp, err = git.api.Groups.AddGroupHook(gid, opts, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, git.groupFeatureError(ctx, "add group hook", gid, resp, err)
+	}
+	return hook.ID, nil
+}
+
+// groupFeatureError map the failure of a premium-only group endpoint, GitLab CE answers 404
+// for a group that exists, so the group is looked up to tell the two apart
+func (git *gitlabServer) groupFeatureError(ctx context.Context, op string, gid interface{}, resp *gitlab.Response, err error) error {
+	if resp != nil && resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%s: group %v: %w", op, gid, ErrPermissionDenied)
+	}
+	if !premiumRequired(resp) {
+		return err
+	}
+	options := &gitlab.GetGroupOptions{
+		WithProjects: gitlab.Bool(false),
+	}
+	resp, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Groups.GetGroup(gid, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("group %v: %w", gid, ErrGroupNotFound)
+		}
+		return err
+	}
+	return fmt.Errorf("%s: %w", op, ErrPremiumRequired)
+}
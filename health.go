@@ -0,0 +1,69 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Ping check the url and token work, ErrUnauthorized and ErrUnreachable tell a bad
+// token from a host that cannot be reached
+func (git *gitlabServer) Ping() error {
+	return git.PingWithContext(context.Background())
+}
+
+// PingWithContext is like Ping but binds the request to ctx
+func (git *gitlabServer) PingWithContext(ctx context.Context) error {
+	_, err := git.VersionWithContext(ctx)
+	return err
+}
+
+// Version get the GitLab version, e.g. 15.4.0-ee
+func (git *gitlabServer) Version() (string, error) {
+	return git.VersionWithContext(context.Background())
+}
+
+// VersionWithContext is like Version but binds the request to ctx
+func (git *gitlabServer) VersionWithContext(ctx context.Context) (string, error) {
+	if err := git.requireClient(); err != nil {
+		return "", err
+	}
+	var version *gitlab.Version
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		version, resp, err = git.api.Version.GetVersion(optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp == nil || resp.Response == nil {
+			return "", fmt.Errorf("%s: %w: %v", git.api.BaseURL(), ErrUnreachable, err)
+		}
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return "", fmt.Errorf("%s: %w", git.api.BaseURL(), ErrUnauthorized)
+		}
+		return "", err
+	}
+	return version.Version, nil
+}
+
+// CurrentUser get the account the token belongs to, including username, id and email
+func (git *gitlabServer) CurrentUser() (map[string]interface{}, error) {
+	return git.CurrentUserWithContext(context.Background())
+}
+
+// CurrentUserWithContext is like CurrentUser but binds the request to ctx
+func (git *gitlabServer) CurrentUserWithContext(ctx context.Context) (map[string]interface{}, error) {
+	var user *gitlab.User
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		user, resp, err = git.api.Users.CurrentUser(optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return nil, fmt.Errorf("current user: %w", ErrUnauthorized)
+		}
+		return nil, err
+	}
+	return convertToMap(user)
+}
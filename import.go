@@ -0,0 +1,63 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// GetImportStatus get the import status of a project, e.g. none, scheduled, started, finished or failed
+func (git *gitlabServer) GetImportStatus(projectId int) (string, error) {
+	return git.GetImportStatusWithContext(context.Background(), projectId)
+}
+
+// GetImportStatusWithContext is like GetImportStatus but binds the request to ctx
+func (git *gitlabServer) GetImportStatusWithContext(ctx context.Context, projectId int) (string, error) {
+	var project *gitlab.Project
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		project, resp, err = git.api.Projects.GetProject(projectId, &gitlab.GetProjectOptions{}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("project id %d: %w", projectId, ErrProjectNotFound)
+		}
+		return "", err
+	}
+	if project.ImportStatus == "failed" {
+		return project.ImportStatus, fmt.Errorf("project id %d: %w: %s", projectId, ErrImportFailed, project.ImportError)
+	}
+	return project.ImportStatus, nil
+}
+
+// WaitForProjectReady poll a forked or imported project until its import is finished
+func (git *gitlabServer) WaitForProjectReady(projectId int, timeout time.Duration) error {
+	return git.WaitForProjectReadyWithContext(context.Background(), projectId, timeout)
+}
+
+// WaitForProjectReadyWithContext is like WaitForProjectReady but stops waiting once ctx is done
+func (git *gitlabServer) WaitForProjectReadyWithContext(ctx context.Context, projectId int, timeout time.Duration) error {
+	ctx, cancel := waitContext(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(git.pollInterval())
+	defer ticker.Stop()
+	for {
+		status, err := git.GetImportStatusWithContext(ctx, projectId)
+		if err != nil {
+			return err
+		}
+		// projects that were never imported report none and are ready right away
+		if status == "finished" || status == "none" || status == "" {
+			return nil
+		}
+		git.logger().Debugf("project %d import status %s", projectId, status)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for project %d: %w", projectId, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListIssues list the issues of the project in state, opened, closed or empty for all
+func (git *gitlabServer) ListIssues(state string) ([]map[string]interface{}, error) {
+	return git.ListIssuesWithContext(context.Background(), state)
+}
+
+// ListIssuesWithContext is like ListIssues but binds the request to ctx
+func (git *gitlabServer) ListIssuesWithContext(ctx context.Context, state string) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListProjectIssuesOptions{ListOptions: git.listOptions()}
+	if state != "" {
+		options.State = &state
+	}
+	var issueSlice []*gitlab.Issue
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list issues: exceeded max pages %d", git.maxPages())
+		}
+		var issues []*gitlab.Issue
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			issues, resp, err = git.api.Issues.ListProjectIssues(int(projectId), options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		issueSlice = append(issueSlice, issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(issueSlice)
+}
+
+// CreateIssue create an issue of the project and return its iid, opts set e.g. the assignee
+func (git *gitlabServer) CreateIssue(title, description string, labels []string, opts ...IssueOption) (int, error) {
+	return git.CreateIssueWithContext(context.Background(), title, description, labels, opts...)
+}
+
+// CreateIssueWithContext is like CreateIssue but binds the request to ctx
+func (git *gitlabServer) CreateIssueWithContext(ctx context.Context, title, description string, labels []string, opts ...IssueOption) (int, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	options := &gitlab.CreateIssueOptions{
+		Title:       &title,
+		Description: &description,
+	}
+	if len(labels) > 0 {
+		issueLabels := gitlab.Labels(labels)
+		options.Labels = &issueLabels
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	var issue *gitlab.Issue
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		issue, resp, err = git.api.Issues.CreateIssue(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return issue.IID, nil
+}
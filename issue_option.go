@@ -0,0 +1,27 @@
+package git
+
+import (
+	"github.com/xanzy/go-gitlab"
+)
+
+// IssueOption override a field of the options used to create an issue
+type IssueOption func(*gitlab.CreateIssueOptions)
+
+// WithIssueAssignee assign the issue to the user
+func WithIssueAssignee(userId int) IssueOption {
+	return func(o *gitlab.CreateIssueOptions) {
+		var assigneeIds []int
+		if o.AssigneeIDs != nil {
+			assigneeIds = *o.AssigneeIDs
+		}
+		assigneeIds = append(assigneeIds, userId)
+		o.AssigneeIDs = &assigneeIds
+	}
+}
+
+// WithIssueConfidential mark the issue confidential
+func WithIssueConfidential(confidential bool) IssueOption {
+	return func(o *gitlab.CreateIssueOptions) {
+		o.Confidential = gitlab.Bool(confidential)
+	}
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListJobs list the jobs of a pipeline
+func (git *gitlabServer) ListJobs(pipelineId int) ([]map[string]interface{}, error) {
+	return git.ListJobsWithContext(context.Background(), pipelineId)
+}
+
+// ListJobsWithContext is like ListJobs but binds the request to ctx
+func (git *gitlabServer) ListJobsWithContext(ctx context.Context, pipelineId int) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListJobsOptions{ListOptions: git.listOptions()}
+	var jobSlice []*gitlab.Job
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list jobs: exceeded max pages %d", git.maxPages())
+		}
+		var jobs []*gitlab.Job
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			jobs, resp, err = git.api.Jobs.ListPipelineJobs(int(projectId), pipelineId, options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		jobSlice = append(jobSlice, jobs...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(jobSlice)
+}
+
+// DownloadArtifacts stream the artifacts zip archive of a job without buffering it in memory,
+// the caller is responsible for closing the returned reader
+func (git *gitlabServer) DownloadArtifacts(jobId int) (io.ReadCloser, error) {
+	return git.DownloadArtifactsWithContext(context.Background(), jobId)
+}
+
+// DownloadArtifactsWithContext is like DownloadArtifacts but binds the request to ctx
+func (git *gitlabServer) DownloadArtifactsWithContext(ctx context.Context, jobId int) (io.ReadCloser, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("projects/%d/jobs/%d/artifacts", int(projectId), jobId)
+	req, err := git.api.NewRequest(http.MethodGet, u, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return git.openStream(req, fmt.Errorf("artifacts of job %d: %w", jobId, ErrArtifactsNotFound))
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListLabels list the labels of the project
+func (git *gitlabServer) ListLabels() ([]map[string]interface{}, error) {
+	return git.ListLabelsWithContext(context.Background())
+}
+
+// ListLabelsWithContext is like ListLabels but binds the request to ctx
+func (git *gitlabServer) ListLabelsWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListLabelsOptions{ListOptions: git.listOptions()}
+	var labelSlice []*gitlab.Label
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list labels: exceeded max pages %d", git.maxPages())
+		}
+		var labels []*gitlab.Label
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			labels, resp, err = git.api.Labels.ListLabels(int(projectId), options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		labelSlice = append(labelSlice, labels...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(labelSlice)
+}
+
+// CreateLabel create a label of the project, color is e.g. #d9534f, an existing label of
+// the same name is left untouched and reported as ok
+func (git *gitlabServer) CreateLabel(name, color, description string) (string, error) {
+	return git.CreateLabelWithContext(context.Background(), name, color, description)
+}
+
+// CreateLabelWithContext is like CreateLabel but binds the request to ctx
+func (git *gitlabServer) CreateLabelWithContext(ctx context.Context, name, color, description string) (string, error) {
+	if err := requireArgs("create label", "name", name, "color", color); err != nil {
+		return fmt.Sprintf("create label: <%s> error", name), err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.CreateLabelOptions{
+		Name:        &name,
+		Color:       &color,
+		Description: &description,
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Labels.CreateLabel(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if isNameTaken(resp, err) {
+			return fmt.Sprintf("create label: <%s> ok, already exists", name), nil
+		}
+		return fmt.Sprintf("create label: <%s> error", name), err
+	}
+	return fmt.Sprintf("create label: <%s> ok", name), nil
+}
+
+// DeleteLabel delete a label of the project by name
+func (git *gitlabServer) DeleteLabel(name string) (string, error) {
+	return git.DeleteLabelWithContext(context.Background(), name)
+}
+
+// DeleteLabelWithContext is like DeleteLabel but binds the request to ctx
+func (git *gitlabServer) DeleteLabelWithContext(ctx context.Context, name string) (string, error) {
+	if err := requireArgs("delete label", "name", name); err != nil {
+		return fmt.Sprintf("delete label: <%s> error", name), err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.DeleteLabelOptions{Name: &name}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.Labels.DeleteLabel(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("delete label: <%s> error", name), err
+	}
+	return fmt.Sprintf("delete label: <%s> ok", name), nil
+}
@@ -0,0 +1,22 @@
+package git
+
+// Logger receive the diagnostics of the gitlab server
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// nopLogger discard every message, it is used when no Logger is set
+type nopLogger struct{}
+
+func (nopLogger) Debugf(format string, args ...interface{}) {}
+
+func (nopLogger) Errorf(format string, args ...interface{}) {}
+
+// logger return the configured Logger, or a no-op one
+func (git *gitlabServer) logger() Logger {
+	if git.Logger == nil {
+		return nopLogger{}
+	}
+	return git.Logger
+}
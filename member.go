@@ -0,0 +1,130 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListProjectMembers list the direct members of the project
+func (git *gitlabServer) ListProjectMembers() ([]map[string]interface{}, error) {
+	return git.ListProjectMembersWithContext(context.Background())
+}
+
+// ListProjectMembersWithContext is like ListProjectMembers but binds the request to ctx
+func (git *gitlabServer) ListProjectMembersWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListProjectMembersOptions{
+		ListOptions: git.listOptions(),
+	}
+	var memberSlice []*gitlab.ProjectMember
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list project members: exceeded max pages %d", git.maxPages())
+		}
+		var members []*gitlab.ProjectMember
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			members, resp, err = git.api.ProjectMembers.ListProjectMembers(int(projectId), options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		memberSlice = append(memberSlice, members...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(memberSlice)
+}
+
+// AddProjectMember add a user to the project with the access level
+func (git *gitlabServer) AddProjectMember(userId int, accessLevel gitlab.AccessLevelValue) (string, error) {
+	return git.AddProjectMemberWithContext(context.Background(), userId, accessLevel)
+}
+
+// AddProjectMemberWithContext is like AddProjectMember but binds the request to ctx
+func (git *gitlabServer) AddProjectMemberWithContext(ctx context.Context, userId int, accessLevel gitlab.AccessLevelValue) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.AddProjectMemberOptions{
+		UserID:      userId,
+		AccessLevel: gitlab.AccessLevel(accessLevel),
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.ProjectMembers.AddProjectMember(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return fmt.Sprintf("add project member: <%d> error", userId), fmt.Errorf("user %d: %w", userId, ErrMemberExists)
+		}
+		return fmt.Sprintf("add project member: <%d> error", userId), err
+	}
+	return fmt.Sprintf("add project member: <%d> ok", userId), nil
+}
+
+// RemoveProjectMember remove a user from the project
+func (git *gitlabServer) RemoveProjectMember(userId int) (string, error) {
+	return git.RemoveProjectMemberWithContext(context.Background(), userId)
+}
+
+// RemoveProjectMemberWithContext is like RemoveProjectMember but binds the request to ctx
+func (git *gitlabServer) RemoveProjectMemberWithContext(ctx context.Context, userId int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.ProjectMembers.DeleteProjectMember(int(projectId), userId, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("remove project member: <%d> error", userId), err
+	}
+	return fmt.Sprintf("remove project member: <%d> ok", userId), nil
+}
+
+// ListGroupMembers list the direct members of the configured group
+func (git *gitlabServer) ListGroupMembers() ([]map[string]interface{}, error) {
+	return git.ListGroupMembersWithContext(context.Background())
+}
+
+// ListGroupMembersWithContext is like ListGroupMembers but binds the request to ctx
+func (git *gitlabServer) ListGroupMembersWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	gid, err := git.groupRef("list group members")
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListGroupMembersOptions{
+		ListOptions: git.listOptions(),
+	}
+	var memberSlice []*gitlab.GroupMember
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list group members: exceeded max pages %d", git.maxPages())
+		}
+		var members []*gitlab.GroupMember
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			members, resp, err = git.api.Groups.ListGroupMembers(gid, options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		memberSlice = append(memberSlice, members...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(memberSlice)
+}
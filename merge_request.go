@@ -0,0 +1,344 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListMergeRequests list the merge requests of the project, each with iid, title, author,
+// source_branch, target_branch and web_url, an empty state or targetBranch does not filter,
+// labels keep the merge requests carrying all of them
+func (git *gitlabServer) ListMergeRequests(state, targetBranch string, labels ...string) ([]map[string]interface{}, error) {
+	return git.ListMergeRequestsWithContext(context.Background(), state, targetBranch, labels...)
+}
+
+// ListMergeRequestsWithContext is like ListMergeRequests but binds the request to ctx
+func (git *gitlabServer) ListMergeRequestsWithContext(ctx context.Context, state, targetBranch string, labels ...string) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListProjectMergeRequestsOptions{ListOptions: git.listOptions()}
+	if state != "" {
+		options.State = &state
+	}
+	if targetBranch != "" {
+		options.TargetBranch = &targetBranch
+	}
+	if len(labels) > 0 {
+		mrLabels := gitlab.Labels(labels)
+		options.Labels = &mrLabels
+	}
+	var mrSlice []*gitlab.MergeRequest
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list merge requests: exceeded max pages %d", git.maxPages())
+		}
+		var mrs []*gitlab.MergeRequest
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			mrs, resp, err = git.api.MergeRequests.ListProjectMergeRequests(int(projectId), options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		mrSlice = append(mrSlice, mrs...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(mrSlice)
+}
+
+// CreateMergeRequest create a merge request and return its iid
+func (git *gitlabServer) CreateMergeRequest(sourceBranch, targetBranch, title, description string) (int, error) {
+	return git.CreateMergeRequestWithContext(context.Background(), sourceBranch, targetBranch, title, description)
+}
+
+// CreateMergeRequestWithContext is like CreateMergeRequest but binds the request to ctx
+func (git *gitlabServer) CreateMergeRequestWithContext(ctx context.Context, sourceBranch, targetBranch, title, description string) (int, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var compare *gitlab.Compare
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		compare, resp, err = git.api.Repositories.Compare(int(projectId), &gitlab.CompareOptions{
+			From: &targetBranch,
+			To:   &sourceBranch,
+		}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(compare.Commits) == 0 {
+		return 0, fmt.Errorf("%s -> %s: %w", sourceBranch, targetBranch, ErrNoChanges)
+	}
+	options := &gitlab.CreateMergeRequestOptions{
+		Title:        &title,
+		Description:  &description,
+		SourceBranch: &sourceBranch,
+		TargetBranch: &targetBranch,
+	}
+	var mr *gitlab.MergeRequest
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		mr, resp, err = git.api.MergeRequests.CreateMergeRequest(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return mr.IID, nil
+}
+
+// MergeMergeRequest accept a merge request
+func (git *gitlabServer) MergeMergeRequest(mrIID int) (string, error) {
+	return git.MergeMergeRequestWithContext(context.Background(), mrIID)
+}
+
+// MergeMergeRequestWithContext is like MergeMergeRequest but binds the request to ctx
+func (git *gitlabServer) MergeMergeRequestWithContext(ctx context.Context, mrIID int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.AcceptMergeRequestOptions{}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.MergeRequests.AcceptMergeRequest(int(projectId), mrIID, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("merge request: !%d error", mrIID), err
+	}
+	return fmt.Sprintf("merge request: !%d ok", mrIID), nil
+}
+
+// CloseMergeRequest close the merge request and return its resulting state, a merge request
+// that is already closed is left as is
+func (git *gitlabServer) CloseMergeRequest(mrIID int) (string, error) {
+	return git.CloseMergeRequestWithContext(context.Background(), mrIID)
+}
+
+// CloseMergeRequestWithContext is like CloseMergeRequest but binds the request to ctx
+func (git *gitlabServer) CloseMergeRequestWithContext(ctx context.Context, mrIID int) (string, error) {
+	return git.setMergeRequestState(ctx, mrIID, "close", "closed")
+}
+
+// ReopenMergeRequest reopen the closed merge request and return its resulting state, a merge
+// request that is already open is left as is
+func (git *gitlabServer) ReopenMergeRequest(mrIID int) (string, error) {
+	return git.ReopenMergeRequestWithContext(context.Background(), mrIID)
+}
+
+// ReopenMergeRequestWithContext is like ReopenMergeRequest but binds the request to ctx
+func (git *gitlabServer) ReopenMergeRequestWithContext(ctx context.Context, mrIID int) (string, error) {
+	return git.setMergeRequestState(ctx, mrIID, "reopen", "opened")
+}
+
+// setMergeRequestState send stateEvent to the merge request unless it is already in state,
+// a merged merge request can neither be closed nor reopened
+func (git *gitlabServer) setMergeRequestState(ctx context.Context, mrIID int, stateEvent, state string) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	var mr *gitlab.MergeRequest
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		mr, resp, err = git.api.MergeRequests.GetMergeRequest(int(projectId), mrIID, &gitlab.GetMergeRequestsOptions{}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return "", err
+	}
+	switch mr.State {
+	case state:
+		git.logger().Debugf("merge request !%d is already %s", mrIID, state)
+		return mr.State, nil
+	case "merged":
+		return mr.State, fmt.Errorf("%s merge request !%d: already merged: %w", stateEvent, mrIID, ErrInvalidArgument)
+	}
+	options := &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.String(stateEvent),
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		mr, resp, err = git.api.MergeRequests.UpdateMergeRequest(int(projectId), mrIID, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return "", err
+	}
+	return mr.State, nil
+}
+
+// ApproveMergeRequest approve the merge request as the token owner and return the approval state
+func (git *gitlabServer) ApproveMergeRequest(mrIID int) (string, error) {
+	return git.ApproveMergeRequestWithContext(context.Background(), mrIID)
+}
+
+// ApproveMergeRequestWithContext is like ApproveMergeRequest but binds the request to ctx
+func (git *gitlabServer) ApproveMergeRequestWithContext(ctx context.Context, mrIID int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	var approvals *gitlab.MergeRequestApprovals
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		approvals, resp, err = git.api.MergeRequestApprovals.ApproveMergeRequest(int(projectId), mrIID, &gitlab.ApproveMergeRequestOptions{}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("approve merge request: !%d error", mrIID), err
+	}
+	return fmt.Sprintf("approve merge request: !%d ok, approved: %t, approvals left: %d", mrIID, approvals.Approved, approvals.ApprovalsLeft), nil
+}
+
+// UnapproveMergeRequest withdraw the token owner's approval of the merge request
+func (git *gitlabServer) UnapproveMergeRequest(mrIID int) (string, error) {
+	return git.UnapproveMergeRequestWithContext(context.Background(), mrIID)
+}
+
+// UnapproveMergeRequestWithContext is like UnapproveMergeRequest but binds the request to ctx
+func (git *gitlabServer) UnapproveMergeRequestWithContext(ctx context.Context, mrIID int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.MergeRequestApprovals.UnapproveMergeRequest(int(projectId), mrIID, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("unapprove merge request: !%d error", mrIID), err
+	}
+	return fmt.Sprintf("unapprove merge request: !%d ok", mrIID), nil
+}
+
+// WaitForMergeable poll the merge request until GitLab finished checking whether it can be merged,
+// ErrMergeConflict is returned when it cannot
+func (git *gitlabServer) WaitForMergeable(mrIID int, timeout time.Duration) error {
+	return git.WaitForMergeableWithContext(context.Background(), mrIID, timeout)
+}
+
+// WaitForMergeableWithContext is like WaitForMergeable but stops waiting once ctx is done
+func (git *gitlabServer) WaitForMergeableWithContext(ctx context.Context, mrIID int, timeout time.Duration) error {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := waitContext(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(git.pollInterval())
+	defer ticker.Stop()
+	for {
+		var mr *gitlab.MergeRequest
+		_, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			mr, resp, err = git.api.MergeRequests.GetMergeRequest(int(projectId), mrIID, &gitlab.GetMergeRequestsOptions{}, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return err
+		}
+		switch mr.MergeStatus {
+		case "can_be_merged":
+			return nil
+		case "cannot_be_merged":
+			return fmt.Errorf("merge request !%d: %w", mrIID, ErrMergeConflict)
+		}
+		git.logger().Debugf("merge request !%d merge status %s", mrIID, mr.MergeStatus)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for merge request !%d: %w", mrIID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// PromoteBranch fast-forward target to source through a merge request and return the new target sha
+func (git *gitlabServer) PromoteBranch(source, target string, deleteSource bool, timeout time.Duration) (string, error) {
+	return git.PromoteBranchWithContext(context.Background(), source, target, deleteSource, timeout)
+}
+
+// PromoteBranchWithContext is like PromoteBranch but binds the requests to ctx
+func (git *gitlabServer) PromoteBranchWithContext(ctx context.Context, source, target string, deleteSource bool, timeout time.Duration) (string, error) {
+	if err := requireArgs("promote branch", "source", source, "target", target); err != nil {
+		return "", err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	var project *gitlab.Project
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		project, resp, err = git.api.Projects.GetProject(int(projectId), &gitlab.GetProjectOptions{}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return "", err
+	}
+	// the merge method is a project setting, any other than ff makes GitLab create a merge commit
+	if project.MergeMethod != gitlab.FastForwardMerge {
+		return "", fmt.Errorf("promote %s to %s: project merge method is %s: %w", source, target, project.MergeMethod, ErrNotFastForward)
+	}
+	mrIID, err := git.openMergeRequest(ctx, int(projectId), source, target)
+	if err != nil {
+		return "", fmt.Errorf("promote %s to %s: %w", source, target, err)
+	}
+	if err := git.WaitForMergeableWithContext(ctx, mrIID, timeout); err != nil {
+		return "", fmt.Errorf("promote %s to %s: %w", source, target, err)
+	}
+	var mr *gitlab.MergeRequest
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		mr, resp, err = git.api.MergeRequests.GetMergeRequest(int(projectId), mrIID, &gitlab.GetMergeRequestsOptions{
+			IncludeDivergedCommitsCount: gitlab.Bool(true),
+		}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return "", err
+	}
+	// target moved past the base of source, only a rebase would make the merge a fast-forward
+	if mr.DivergedCommitsCount > 0 {
+		return "", fmt.Errorf("promote %s to %s: %s is %d commits ahead: %w", source, target, target, mr.DivergedCommitsCount, ErrNotFastForward)
+	}
+	options := &gitlab.AcceptMergeRequestOptions{
+		ShouldRemoveSourceBranch: gitlab.Bool(deleteSource),
+		// refuse the merge if source changed after the checks above
+		SHA: gitlab.String(mr.SHA),
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		mr, resp, err = git.api.MergeRequests.AcceptMergeRequest(int(projectId), mrIID, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return "", fmt.Errorf("promote %s to %s: merge request !%d: %w", source, target, mrIID, err)
+	}
+	git.logger().Debugf("promote %s to %s: merge request !%d merged", source, target, mrIID)
+	return mr.SHA, nil
+}
+
+// openMergeRequest return the iid of the open merge request from source into target, creating it when there is none
+func (git *gitlabServer) openMergeRequest(ctx context.Context, projectId int, source, target string) (int, error) {
+	options := &gitlab.ListProjectMergeRequestsOptions{
+		State:        gitlab.String("opened"),
+		SourceBranch: gitlab.String(source),
+		TargetBranch: gitlab.String(target),
+	}
+	var mrs []*gitlab.MergeRequest
+	_, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		mrs, resp, err = git.api.MergeRequests.ListProjectMergeRequests(projectId, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(mrs) > 0 {
+		git.logger().Debugf("reuse merge request !%d from %s to %s", mrs[0].IID, source, target)
+		return mrs[0].IID, nil
+	}
+	return git.CreateMergeRequestWithContext(ctx, source, target, fmt.Sprintf("Promote %s to %s", source, target), "")
+}
@@ -0,0 +1,80 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// CreateCommitComment comment on the commit sha, e.g. to record where it was deployed, and
+// return the note id
+func (git *gitlabServer) CreateCommitComment(sha, note string) (int, error) {
+	return git.CreateCommitCommentWithContext(context.Background(), sha, note)
+}
+
+// CreateCommitCommentWithContext is like CreateCommitComment but binds the request to ctx
+func (git *gitlabServer) CreateCommitCommentWithContext(ctx context.Context, sha, note string) (int, error) {
+	if err := requireArgs("create commit comment", "sha", sha, "note", note); err != nil {
+		return 0, err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// the commit comments endpoint returns no id, a commit discussion does, go-gitlab's
+	// CreateCommitDiscussion takes the commit as an int so the request is built here
+	u := fmt.Sprintf("projects/%d/repository/commits/%s/discussions", int(projectId), gitlab.PathEscape(sha))
+	options := &gitlab.CreateCommitDiscussionOptions{
+		Body: gitlab.String(note),
+	}
+	var discussion *gitlab.Discussion
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		req, err := git.api.NewRequest(http.MethodPost, u, options, optionFuncs)
+		if err != nil {
+			return nil, err
+		}
+		discussion = new(gitlab.Discussion)
+		resp, err = git.api.Do(req, discussion)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return 0, fmt.Errorf("commit %s: %w", sha, ErrCommitNotFound)
+		}
+		return 0, err
+	}
+	if len(discussion.Notes) == 0 {
+		return 0, fmt.Errorf("create commit comment on %s: no note returned", sha)
+	}
+	return discussion.Notes[0].ID, nil
+}
+
+// CreateMergeRequestNote comment on the merge request and return the note id
+func (git *gitlabServer) CreateMergeRequestNote(mrIID int, note string) (int, error) {
+	return git.CreateMergeRequestNoteWithContext(context.Background(), mrIID, note)
+}
+
+// CreateMergeRequestNoteWithContext is like CreateMergeRequestNote but binds the request to ctx
+func (git *gitlabServer) CreateMergeRequestNoteWithContext(ctx context.Context, mrIID int, note string) (int, error) {
+	if err := requireArgs("create merge request note", "note", note); err != nil {
+		return 0, err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	options := &gitlab.CreateMergeRequestNoteOptions{
+		Body: gitlab.String(note),
+	}
+	var created *gitlab.Note
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		created, resp, err = git.api.Notes.CreateMergeRequestNote(int(projectId), mrIID, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
@@ -0,0 +1,199 @@
+package git
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/xanzy/go-gitlab"
+)
+
+// KeysetPagination switch the commit and project listing to GitLab keyset pagination,
+// which stays fast and consistent on large result sets, endpoints without keyset support
+// fall back to offset pagination and are still walked through the Link header
+type KeysetPagination struct {
+	// OrderBy is the column the keyset is ordered by, defaults to id
+	OrderBy string
+	// Sort is asc or desc, defaults to asc
+	Sort string
+}
+
+// keysetOptions return the request options starting a keyset paginated list, nil in offset mode
+func (git *gitlabServer) keysetOptions() []gitlab.RequestOptionFunc {
+	if git.Keyset == nil {
+		return nil
+	}
+	orderBy, sort := git.Keyset.OrderBy, git.Keyset.Sort
+	if orderBy == "" {
+		orderBy = "id"
+	}
+	if sort == "" {
+		sort = "asc"
+	}
+	values := url.Values{
+		"pagination": {"keyset"},
+		"order_by":   {orderBy},
+		"sort":       {sort},
+	}
+	return []gitlab.RequestOptionFunc{withQuery(values, false)}
+}
+
+// nextKeysetOptions return the request options fetching the page linked as next by resp,
+// false on the last page
+func nextKeysetOptions(resp *gitlab.Response) ([]gitlab.RequestOptionFunc, bool) {
+	next := nextLink(resp)
+	if next == nil {
+		return nil, false
+	}
+	return []gitlab.RequestOptionFunc{withQuery(next, true)}, true
+}
+
+// withQuery set values on the request query, replace drops the parameters encoded by go-gitlab
+func withQuery(values url.Values, replace bool) gitlab.RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		query := req.URL.Query()
+		if replace {
+			query = url.Values{}
+		}
+		for key, value := range values {
+			query[key] = value
+		}
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+}
+
+// nextLink return the query of the rel="next" url of the Link header, nil when there is none
+func nextLink(resp *gitlab.Response) url.Values {
+	if resp == nil || resp.Response == nil {
+		return nil
+	}
+	for _, link := range strings.Split(resp.Header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		isNext := false
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				isNext = true
+			}
+		}
+		if !isNext {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+		if err != nil {
+			return nil
+		}
+		return u.Query()
+	}
+	return nil
+}
+
+// PageInfo is the pagination metadata of a single page, GitLab leaves TotalItems and
+// TotalPages at zero when counting would be too expensive, e.g. beyond 10,000 items
+type PageInfo struct {
+	CurrentPage int
+	PerPage     int
+	NextPage    int
+	TotalPages  int
+	TotalItems  int
+}
+
+// pageInfo read the pagination metadata of resp
+func pageInfo(resp *gitlab.Response) PageInfo {
+	return PageInfo{
+		CurrentPage: resp.CurrentPage,
+		PerPage:     resp.ItemsPerPage,
+		NextPage:    resp.NextPage,
+		TotalPages:  resp.TotalPages,
+		TotalItems:  resp.TotalItems,
+	}
+}
+
+// ListProjectPage list a single page of the group projects matching filter along with its
+// pagination metadata, a zero filter lists them all like ListProject
+func (git *gitlabServer) ListProjectPage(page int, filter ProjectFilter) ([]map[string]interface{}, PageInfo, error) {
+	return git.ListProjectPageWithContext(context.Background(), page, filter)
+}
+
+// ListProjectPageWithContext is like ListProjectPage but binds the request to ctx
+func (git *gitlabServer) ListProjectPageWithContext(ctx context.Context, page int, filter ProjectFilter) ([]map[string]interface{}, PageInfo, error) {
+	gid, err := git.groupRef("list group projects")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	// pages are addressed by number, so the listing stays in offset mode even with Keyset set
+	lp, filterOptions := git.groupProjectsOptions(filter)
+	lp.Page = page
+	var projects []*gitlab.Project
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		projects, resp, err = git.api.Groups.ListGroupProjects(gid, lp, append(optionFuncs, filterOptions...)...)
+		return
+	})
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	data, err := convertToMaps(projects)
+	return data, pageInfo(resp), err
+}
+
+// ListProjectCommitPage list a single page of the branch commits along with its pagination metadata
+func (git *gitlabServer) ListProjectCommitPage(branch string, page int, opts ...CommitOption) ([]map[string]interface{}, PageInfo, error) {
+	return git.ListProjectCommitPageWithContext(context.Background(), branch, page, opts...)
+}
+
+// ListProjectCommitPageWithContext is like ListProjectCommitPage but binds the request to ctx
+func (git *gitlabServer) ListProjectCommitPageWithContext(ctx context.Context, branch string, page int, opts ...CommitOption) ([]map[string]interface{}, PageInfo, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	options := &gitlab.ListCommitsOptions{
+		ListOptions: git.listOptions(),
+		RefName:     &branch,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	options.Page = page
+	var commits []*gitlab.Commit
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		commits, resp, err = git.api.Commits.ListCommits(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	data, err := convertToMaps(commits)
+	return data, pageInfo(resp), err
+}
+
+// ListTagsPage list a single page of the project tags along with its pagination metadata
+func (git *gitlabServer) ListTagsPage(page int) ([]map[string]interface{}, PageInfo, error) {
+	return git.ListTagsPageWithContext(context.Background(), page)
+}
+
+// ListTagsPageWithContext is like ListTagsPage but binds the request to ctx
+func (git *gitlabServer) ListTagsPageWithContext(ctx context.Context, page int) ([]map[string]interface{}, PageInfo, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	options := &gitlab.ListTagsOptions{
+		ListOptions: git.listOptions(),
+	}
+	options.Page = page
+	var tags []*gitlab.Tag
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		tags, resp, err = git.api.Tags.ListTags(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	data, err := convertToMaps(tags)
+	return data, pageInfo(resp), err
+}
@@ -0,0 +1,151 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+const defaultPollInterval = 5 * time.Second
+
+// pipelineTerminalStatus are the pipeline statuses that will not change anymore
+var pipelineTerminalStatus = map[string]bool{
+	"success":  true,
+	"failed":   true,
+	"canceled": true,
+	"skipped":  true,
+	"manual":   true,
+}
+
+// pollInterval return how long to wait between two polls
+func (git *gitlabServer) pollInterval() time.Duration {
+	if git.PollInterval <= 0 {
+		return defaultPollInterval
+	}
+	return git.PollInterval
+}
+
+// waitContext bound ctx by timeout, a zero or negative timeout leaves ctx as is
+func waitContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// TriggerPipeline create a pipeline on ref and return its id
+func (git *gitlabServer) TriggerPipeline(ref string) (int, error) {
+	return git.TriggerPipelineWithContext(context.Background(), ref)
+}
+
+// TriggerPipelineWithContext is like TriggerPipeline but binds the request to ctx
+func (git *gitlabServer) TriggerPipelineWithContext(ctx context.Context, ref string) (int, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	options := &gitlab.CreatePipelineOptions{
+		Ref: &ref,
+	}
+	var pipeline *gitlab.Pipeline
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		pipeline, resp, err = git.api.Pipelines.CreatePipeline(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return pipeline.ID, nil
+}
+
+// ListPipelines list the pipelines of the project newest first, each with id, status, ref, sha
+// and web_url, an empty ref or status does not filter
+func (git *gitlabServer) ListPipelines(ref, status string) ([]map[string]interface{}, error) {
+	return git.ListPipelinesWithContext(context.Background(), ref, status)
+}
+
+// ListPipelinesWithContext is like ListPipelines but binds the request to ctx
+func (git *gitlabServer) ListPipelinesWithContext(ctx context.Context, ref, status string) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListProjectPipelinesOptions{ListOptions: git.listOptions()}
+	if ref != "" {
+		options.Ref = &ref
+	}
+	if status != "" {
+		options.Status = gitlab.BuildState(gitlab.BuildStateValue(status))
+	}
+	var pipelineSlice []*gitlab.PipelineInfo
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list pipelines: exceeded max pages %d", git.maxPages())
+		}
+		var pipelines []*gitlab.PipelineInfo
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			pipelines, resp, err = git.api.Pipelines.ListProjectPipelines(int(projectId), options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		pipelineSlice = append(pipelineSlice, pipelines...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(pipelineSlice)
+}
+
+// GetPipelineStatus get the status of a pipeline, e.g. running, success or failed
+func (git *gitlabServer) GetPipelineStatus(pipelineId int) (string, error) {
+	return git.GetPipelineStatusWithContext(context.Background(), pipelineId)
+}
+
+// GetPipelineStatusWithContext is like GetPipelineStatus but binds the request to ctx
+func (git *gitlabServer) GetPipelineStatusWithContext(ctx context.Context, pipelineId int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	var pipeline *gitlab.Pipeline
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		pipeline, resp, err = git.api.Pipelines.GetPipeline(int(projectId), pipelineId, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return "", err
+	}
+	return pipeline.Status, nil
+}
+
+// WaitForPipeline poll the pipeline until it reaches a terminal status and return that status
+func (git *gitlabServer) WaitForPipeline(pipelineId int, timeout time.Duration) (string, error) {
+	return git.WaitForPipelineWithContext(context.Background(), pipelineId, timeout)
+}
+
+// WaitForPipelineWithContext is like WaitForPipeline but stops waiting once ctx is done
+func (git *gitlabServer) WaitForPipelineWithContext(ctx context.Context, pipelineId int, timeout time.Duration) (string, error) {
+	ctx, cancel := waitContext(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(git.pollInterval())
+	defer ticker.Stop()
+	for {
+		status, err := git.GetPipelineStatusWithContext(ctx, pipelineId)
+		if err != nil {
+			return status, err
+		}
+		if pipelineTerminalStatus[status] {
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("wait for pipeline %d: %w", pipelineId, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// minProjectSearchLength is the shortest search sent to GitLab, shorter ones may be rejected or match too broadly
+const minProjectSearchLength = 3
+
+// ProjectFilter narrow the group project listing on the server side, zero fields do not filter
+type ProjectFilter struct {
+	// Search match project names and paths containing it
+	Search string
+	// Archived only list archived, or only non-archived, projects
+	Archived *bool
+	// Visibility only list projects of this visibility
+	Visibility gitlab.VisibilityValue
+	// WithProgrammingLanguage only list projects using the language, e.g. Go
+	WithProgrammingLanguage string
+}
+
+// apply set the filter on the listing options, the request options carry what go-gitlab has no field for
+func (f ProjectFilter) apply(lp *gitlab.ListGroupProjectsOptions) []gitlab.RequestOptionFunc {
+	if f.Search != "" {
+		lp.Search = gitlab.String(f.Search)
+	}
+	if f.Archived != nil {
+		lp.Archived = f.Archived
+	}
+	if f.Visibility != "" {
+		lp.Visibility = gitlab.Visibility(f.Visibility)
+	}
+	if f.WithProgrammingLanguage == "" {
+		return nil
+	}
+	return []gitlab.RequestOptionFunc{withQuery(url.Values{"with_programming_language": {f.WithProgrammingLanguage}}, false)}
+}
+
+// projectLookupFilter let GitLab narrow the listing to candidates of ProjectName, the exact
+// match is still done by matchesProject
+func (git *gitlabServer) projectLookupFilter() ProjectFilter {
+	if len(git.ProjectName) < minProjectSearchLength {
+		return ProjectFilter{}
+	}
+	return ProjectFilter{Search: git.ProjectName}
+}
+
+// ListProjectFiltered list the group projects matching filter, see ListProject for all of them
+func (git *gitlabServer) ListProjectFiltered(filter ProjectFilter) ([]map[string]interface{}, error) {
+	return git.ListProjectFilteredWithContext(context.Background(), filter)
+}
+
+// ListProjectFilteredWithContext is like ListProjectFiltered but binds the request to ctx
+func (git *gitlabServer) ListProjectFilteredWithContext(ctx context.Context, filter ProjectFilter) ([]map[string]interface{}, error) {
+	return git.listProjects(ctx, filter)
+}
@@ -0,0 +1,38 @@
+package git
+
+import "strings"
+
+// ProjectMatch select which project field GetProject, GetProjectId and ProjectExists compare
+// ProjectName with
+type ProjectMatch int
+
+const (
+	// MatchName compare with the project name, the default
+	MatchName ProjectMatch = iota
+	// MatchPath compare with the project path, e.g. my-service for a project named My Service
+	MatchPath
+	// MatchNameOrPath accept either the name or the path
+	MatchNameOrPath
+)
+
+// matchesProject report whether the listed project is the configured one
+func (git *gitlabServer) matchesProject(project map[string]interface{}) bool {
+	name, _ := project["name"].(string)
+	path, _ := project["path"].(string)
+	switch git.ProjectMatch {
+	case MatchPath:
+		return git.equalProjectName(path)
+	case MatchNameOrPath:
+		return git.equalProjectName(name) || git.equalProjectName(path)
+	default:
+		return git.equalProjectName(name)
+	}
+}
+
+// equalProjectName compare s with ProjectName, ignoring case when ProjectMatchIgnoreCase is set
+func (git *gitlabServer) equalProjectName(s string) bool {
+	if git.ProjectMatchIgnoreCase {
+		return strings.EqualFold(s, git.ProjectName)
+	}
+	return s == git.ProjectName
+}
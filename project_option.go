@@ -0,0 +1,69 @@
+package git
+
+import "github.com/xanzy/go-gitlab"
+
+// ProjectOption override a field of the options CreateProject sends to GitLab
+type ProjectOption func(*gitlab.CreateProjectOptions)
+
+// WithProjectNamespaceID create the project in the namespace instead of GroupId
+func WithProjectNamespaceID(namespaceId int) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.NamespaceID = gitlab.Int(namespaceId)
+	}
+}
+
+// WithProjectDescription set the project description
+func WithProjectDescription(description string) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.Description = gitlab.String(description)
+	}
+}
+
+// WithProjectVisibility set the project visibility
+func WithProjectVisibility(visibility gitlab.VisibilityValue) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.Visibility = gitlab.Visibility(visibility)
+	}
+}
+
+// WithProjectDefaultBranch set the project default branch
+func WithProjectDefaultBranch(branch string) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.DefaultBranch = gitlab.String(branch)
+	}
+}
+
+// WithProjectMergeRequestsEnabled enable or disable merge requests
+func WithProjectMergeRequestsEnabled(enabled bool) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.MergeRequestsEnabled = gitlab.Bool(enabled)
+	}
+}
+
+// WithProjectSnippetsEnabled enable or disable snippets
+func WithProjectSnippetsEnabled(enabled bool) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.SnippetsEnabled = gitlab.Bool(enabled)
+	}
+}
+
+// WithProjectIssuesEnabled enable or disable issues
+func WithProjectIssuesEnabled(enabled bool) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.IssuesEnabled = gitlab.Bool(enabled)
+	}
+}
+
+// WithProjectWikiEnabled enable or disable the wiki
+func WithProjectWikiEnabled(enabled bool) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.WikiEnabled = gitlab.Bool(enabled)
+	}
+}
+
+// WithProjectJobsEnabled enable or disable CI/CD jobs
+func WithProjectJobsEnabled(enabled bool) ProjectOption {
+	return func(p *gitlab.CreateProjectOptions) {
+		p.JobsEnabled = gitlab.Bool(enabled)
+	}
+}
@@ -0,0 +1,139 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+const defaultProvisionCommitMessage = "provision project files"
+
+// ProvisionGroup describe the group Provision creates before the project
+type ProvisionGroup struct {
+	Name     string
+	Path     string
+	ParentId *int
+}
+
+// ProvisionSpec describe everything Provision sets up for the project named ProjectName
+type ProvisionSpec struct {
+	// Group is created first when set, nil provisions into the configured group
+	Group *ProvisionGroup
+	// ProjectOptions override the default project settings, see CreateProject
+	ProjectOptions []ProjectOption
+	// Hooks are added to the project once created
+	Hooks []*gitlab.AddProjectHookOptions
+	// Branch receives Files, it is required when Files is not empty
+	Branch string
+	// Files map a file path to its content, they are committed at once
+	Files map[string]string
+	// CommitMessage of the files commit, defaults to "provision project files"
+	CommitMessage string
+}
+
+// ProvisionStep is the outcome of one Provision step, Err is nil when it succeeded
+type ProvisionStep struct {
+	Name string
+	Err  error
+}
+
+// ProvisionResult report what Provision did, steps are in execution order and include the rollback
+type ProvisionResult struct {
+	GroupId    int
+	ProjectId  int
+	Steps      []ProvisionStep
+	RolledBack bool
+}
+
+// Provision create the group, the project, its hooks and initial files, when a step fails
+// the project and group it created are deleted again and the failed step is returned
+func (git *gitlabServer) Provision(spec ProvisionSpec) (*ProvisionResult, error) {
+	return git.ProvisionWithContext(context.Background(), spec)
+}
+
+// ProvisionWithContext is like Provision but binds the requests to ctx
+func (git *gitlabServer) ProvisionWithContext(ctx context.Context, spec ProvisionSpec) (*ProvisionResult, error) {
+	if len(spec.Files) > 0 {
+		if err := requireArgs("provision", "branch", spec.Branch); err != nil {
+			return nil, err
+		}
+	}
+	result := &ProvisionResult{}
+	// work on a copy so creating a group does not retarget the receiver
+	server := git.withProject(git.ProjectName)
+	step := func(name string, err error) error {
+		result.Steps = append(result.Steps, ProvisionStep{Name: name, Err: err})
+		if err == nil {
+			return nil
+		}
+		server.rollbackProvision(ctx, result)
+		return fmt.Errorf("provision %s: %s: %w", git.ProjectName, name, err)
+	}
+
+	if spec.Group != nil {
+		groupId, err := server.CreateGroupWithContext(ctx, spec.Group.Name, spec.Group.Path, spec.Group.ParentId)
+		if err := step(fmt.Sprintf("create group %s", spec.Group.Path), err); err != nil {
+			return result, err
+		}
+		result.GroupId = groupId
+		server = server.withGroup(groupId, spec.Group.Name)
+	}
+
+	projectId, _, err := server.CreateProjectWithContext(ctx, spec.ProjectOptions...)
+	if err := step(fmt.Sprintf("create project %s", git.ProjectName), err); err != nil {
+		return result, err
+	}
+	result.ProjectId = projectId
+
+	for _, hook := range spec.Hooks {
+		_, err := server.CreateProjectHookWithContext(ctx, hook)
+		name := "add hook"
+		if hook != nil && hook.URL != nil {
+			name = fmt.Sprintf("add hook %s", *hook.URL)
+		}
+		if err := step(name, err); err != nil {
+			return result, err
+		}
+	}
+
+	if len(spec.Files) > 0 {
+		paths := make([]string, 0, len(spec.Files))
+		for path := range spec.Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		actions := make([]CommitAction, 0, len(paths))
+		for _, path := range paths {
+			actions = append(actions, CommitAction{Action: gitlab.FileCreate, FilePath: path, Content: spec.Files[path]})
+		}
+		commitMessage := spec.CommitMessage
+		if commitMessage == "" {
+			commitMessage = defaultProvisionCommitMessage
+		}
+		_, err := server.CommitMultipleFilesWithContext(ctx, spec.Branch, commitMessage, actions)
+		if err := step(fmt.Sprintf("commit %d files", len(actions)), err); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// rollbackProvision delete the project and group recorded in result, failures are recorded
+// as steps but do not stop the rollback
+func (git *gitlabServer) rollbackProvision(ctx context.Context, result *ProvisionResult) {
+	if result.ProjectId != 0 {
+		_, _, err := git.DeleteProjectByIdWithContext(ctx, result.ProjectId)
+		result.Steps = append(result.Steps, ProvisionStep{Name: fmt.Sprintf("rollback project %d", result.ProjectId), Err: err})
+	}
+	if result.GroupId != 0 {
+		_, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			resp, err = git.api.Groups.DeleteGroup(result.GroupId, optionFuncs...)
+			return
+		})
+		result.Steps = append(result.Steps, ProvisionStep{Name: fmt.Sprintf("rollback group %d", result.GroupId), Err: err})
+	}
+	result.RolledBack = result.ProjectId != 0 || result.GroupId != 0
+	git.logger().Debugf("provision %s rolled back: %v", git.ProjectName, result.RolledBack)
+}
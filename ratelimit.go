@@ -0,0 +1,59 @@
+package git
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// RateLimit is the rate-limit state reported by the last GitLab response carrying the headers
+type RateLimit struct {
+	// Limit is the RateLimit-Limit header, the number of requests allowed per window
+	Limit int
+	// Remaining is the RateLimit-Remaining header
+	Remaining int
+	// Reset is the RateLimit-Reset header, when the window resets
+	Reset time.Time
+	// Updated is when the state was recorded, zero when no response carried the headers yet
+	Updated time.Time
+}
+
+// rateLimitState hold the last RateLimit, shared by the copies of a server since GitLab
+// limits per token, it is safe for concurrent use
+type rateLimitState struct {
+	mu   sync.RWMutex
+	last RateLimit
+}
+
+// RateLimit return the rate-limit state of the last response, the zero value when unknown
+func (git *gitlabServer) RateLimit() RateLimit {
+	if git.rateLimit == nil {
+		return RateLimit{}
+	}
+	git.rateLimit.mu.RLock()
+	defer git.rateLimit.mu.RUnlock()
+	return git.rateLimit.last
+}
+
+// recordRateLimit store the rate-limit headers of resp, responses without them are ignored
+func (git *gitlabServer) recordRateLimit(resp *gitlab.Response) {
+	if git.rateLimit == nil || resp == nil || resp.Response == nil {
+		return
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	rateLimit := RateLimit{Remaining: remaining, Updated: time.Now()}
+	if limit, err := strconv.Atoi(resp.Header.Get("RateLimit-Limit")); err == nil {
+		rateLimit.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.Reset = time.Unix(reset, 0)
+	}
+	git.rateLimit.mu.Lock()
+	git.rateLimit.last = rateLimit
+	git.rateLimit.mu.Unlock()
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ReleaseLink is an asset link attached to a release
+type ReleaseLink struct {
+	Name string
+	URL  string
+}
+
+// ListReleases list all releases of the project
+func (git *gitlabServer) ListReleases() ([]map[string]interface{}, error) {
+	return git.ListReleasesWithContext(context.Background())
+}
+
+// ListReleasesWithContext is like ListReleases but binds the request to ctx
+func (git *gitlabServer) ListReleasesWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := gitlab.ListReleasesOptions(git.listOptions())
+	var releaseSlice []*gitlab.Release
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list releases: exceeded max pages %d", git.maxPages())
+		}
+		var releases []*gitlab.Release
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			releases, resp, err = git.api.Releases.ListReleases(int(projectId), &options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		releaseSlice = append(releaseSlice, releases...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(releaseSlice)
+}
+
+// CreateRelease create a release for an existing tag, optionally attaching asset links
+func (git *gitlabServer) CreateRelease(tagName, name, description string, links ...ReleaseLink) (string, error) {
+	return git.CreateReleaseWithContext(context.Background(), tagName, name, description, links...)
+}
+
+// CreateReleaseWithContext is like CreateRelease but binds the request to ctx
+func (git *gitlabServer) CreateReleaseWithContext(ctx context.Context, tagName, name, description string, links ...ReleaseLink) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.CreateReleaseOptions{
+		TagName:     &tagName,
+		Name:        &name,
+		Description: &description,
+	}
+	if len(links) > 0 {
+		assets := &gitlab.ReleaseAssetsOptions{}
+		for _, link := range links {
+			assets.Links = append(assets.Links, &gitlab.ReleaseAssetLinkOptions{
+				Name: gitlab.String(link.Name),
+				URL:  gitlab.String(link.URL),
+			})
+		}
+		options.Assets = assets
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.Releases.CreateRelease(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("create release: <%s> error", tagName), err
+	}
+	return fmt.Sprintf("create release: <%s> ok", tagName), nil
+}
@@ -0,0 +1,131 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListTree list the entries under path on branch, each entry has type (blob or tree), path and name
+func (git *gitlabServer) ListTree(branch, path string, recursive bool) ([]map[string]interface{}, error) {
+	return git.ListTreeWithContext(context.Background(), branch, path, recursive)
+}
+
+// ListTreeWithContext is like ListTree but binds the request to ctx
+func (git *gitlabServer) ListTreeWithContext(ctx context.Context, branch, path string, recursive bool) ([]map[string]interface{}, error) {
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListTreeOptions{
+		ListOptions: git.listOptions(),
+		Path:        &path,
+		Ref:         &branch,
+		Recursive:   &recursive,
+	}
+	var nodeSlice []*gitlab.TreeNode
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list tree: exceeded max pages %d", git.maxPages())
+		}
+		var nodes []*gitlab.TreeNode
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			nodes, resp, err = git.api.Repositories.ListTree(projectPath, options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		nodeSlice = append(nodeSlice, nodes...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	data := make([]map[string]interface{}, 0, len(nodeSlice))
+	for _, node := range nodeSlice {
+		entry := make(map[string]interface{})
+		entry["type"] = node.Type
+		entry["path"] = node.Path
+		entry["name"] = node.Name
+		data = append(data, entry)
+	}
+	return data, nil
+}
+
+// GetFileBlame get the blame of a file, each entry covers the lines start_line..end_line
+// last changed by commit_id
+func (git *gitlabServer) GetFileBlame(branch, filename string) ([]map[string]interface{}, error) {
+	return git.GetFileBlameWithContext(context.Background(), branch, filename)
+}
+
+// GetFileBlameWithContext is like GetFileBlame but binds the request to ctx
+func (git *gitlabServer) GetFileBlameWithContext(ctx context.Context, branch, filename string) ([]map[string]interface{}, error) {
+	if err := requireArgs("get file blame", "branch", branch, "filename", filename); err != nil {
+		return nil, err
+	}
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.GetFileBlameOptions{
+		Ref: &branch,
+	}
+	var ranges []*gitlab.FileBlameRange
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		ranges, resp, err = git.api.RepositoryFiles.GetFileBlame(projectPath, filename, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("file %s on branch %s: %w", filename, branch, ErrFileNotFound)
+		}
+		return nil, err
+	}
+	data := make([]map[string]interface{}, 0, len(ranges))
+	line := 1
+	for _, r := range ranges {
+		entry := make(map[string]interface{})
+		entry["commit_id"] = r.Commit.ID
+		entry["commit_message"] = r.Commit.Message
+		entry["commit_author"] = r.Commit.AuthorName
+		entry["commit_author_email"] = r.Commit.AuthorEmail
+		entry["commit_date"] = r.Commit.CommittedDate
+		entry["start_line"] = line
+		entry["end_line"] = line + len(r.Lines) - 1
+		entry["lines"] = r.Lines
+		data = append(data, entry)
+		line += len(r.Lines)
+	}
+	return data, nil
+}
+
+// GetFileHistory list the commits of branch that touched the file, newest first, each with
+// the full commit_id, commit_message, commit_author, commit_author_email and commit_date
+func (git *gitlabServer) GetFileHistory(branch, filename string) ([]map[string]interface{}, error) {
+	return git.GetFileHistoryWithContext(context.Background(), branch, filename)
+}
+
+// GetFileHistoryWithContext is like GetFileHistory but binds the request to ctx
+func (git *gitlabServer) GetFileHistoryWithContext(ctx context.Context, branch, filename string) ([]map[string]interface{}, error) {
+	if err := requireArgs("get file history", "branch", branch, "filename", filename); err != nil {
+		return nil, err
+	}
+	commitSlice, err := git.listCommits(ctx, branch, []CommitOption{WithCommitsPath(filename)})
+	if err != nil {
+		return nil, err
+	}
+	data := make([]map[string]interface{}, 0, len(commitSlice))
+	for _, commit := range commitSlice {
+		data = append(data, map[string]interface{}{
+			"commit_id":           commit.ID,
+			"commit_message":      commit.Message,
+			"commit_author":       commit.AuthorName,
+			"commit_author_email": commit.AuthorEmail,
+			"commit_date":         commit.CommittedDate,
+		})
+	}
+	return data, nil
+}
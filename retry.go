@@ -0,0 +1,87 @@
+package git
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+const (
+	defaultBaseBackoff = 500 * time.Millisecond
+	defaultTimeout     = 30 * time.Second
+)
+
+// requestFunc is a single go-gitlab call receiving the request options to apply
+type requestFunc func(optionFuncs ...gitlab.RequestOptionFunc) (*gitlab.Response, error)
+
+// do run fn bound to ctx, retrying it up to MaxRetries times
+func (git *gitlabServer) do(ctx context.Context, fn requestFunc) (*gitlab.Response, error) {
+	if err := git.requireClient(); err != nil {
+		return nil, err
+	}
+	// Timeout bounds the call and its retries unless the caller already set a deadline
+	if _, ok := ctx.Deadline(); !ok {
+		if timeout := git.timeout(); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := fn(gitlab.WithContext(ctx))
+		git.recordRateLimit(resp)
+		if err == nil || attempt >= git.MaxRetries || !shouldRetry(resp) {
+			return resp, err
+		}
+		// back off exponentially or as long as Retry-After asks, giving up when the
+		// deadline would pass first
+		wait := git.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+		git.logger().Debugf("request failed with %s, retry %d/%d in %s", resp.Status, attempt+1, git.MaxRetries, wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// timeout return the configured Timeout, zero means the default and negative disables it
+func (git *gitlabServer) timeout() time.Duration {
+	if git.Timeout == 0 {
+		return defaultTimeout
+	}
+	return git.Timeout
+}
+
+// shouldRetry report whether the response is worth retrying, 404 and other 4xx never are
+func shouldRetry(resp *gitlab.Response) bool {
+	if resp == nil || resp.Response == nil {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff return how long to wait before the next attempt
+func (git *gitlabServer) backoff(attempt int, resp *gitlab.Response) time.Duration {
+	if after := resp.Header.Get("Retry-After"); after != "" {
+		if seconds, err := strconv.Atoi(after); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if t, err := http.ParseTime(after); err == nil {
+			return time.Until(t)
+		}
+	}
+	base := git.BaseBackoff
+	if base <= 0 {
+		base = defaultBaseBackoff
+	}
+	return base << uint(attempt)
+}
@@ -0,0 +1,158 @@
+package git
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// fakeGroups serve the group projects from memory, the methods it does not override panic
+type fakeGroups struct {
+	groupsService
+	projects []*gitlab.Project
+	lists    int
+	// missing makes GetGroup answer 404, hooksStatus is the status ListGroupHooks fails with
+	missing     bool
+	hooksStatus int
+}
+
+func (f *fakeGroups) GetGroup(gid interface{}, opt *gitlab.GetGroupOptions, options ...gitlab.RequestOptionFunc) (*gitlab.Group, *gitlab.Response, error) {
+	if f.missing {
+		resp := fakeResponse(http.StatusNotFound)
+		return nil, resp, &gitlab.ErrorResponse{Response: resp.Response, Message: "404 Group Not Found"}
+	}
+	return &gitlab.Group{ID: 1, FullPath: "group"}, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGroups) ListGroupHooks(gid interface{}, opt *gitlab.ListGroupHooksOptions, options ...gitlab.RequestOptionFunc) ([]*gitlab.GroupHook, *gitlab.Response, error) {
+	if f.hooksStatus != 0 {
+		resp := fakeResponse(f.hooksStatus)
+		return nil, resp, &gitlab.ErrorResponse{Response: resp.Response, Message: http.StatusText(f.hooksStatus)}
+	}
+	return []*gitlab.GroupHook{{ID: 5, URL: "https://hooks.example.com"}}, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGroups) ListGroupProjects(gid interface{}, opt *gitlab.ListGroupProjectsOptions, options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+	f.lists++
+	return f.projects, fakeResponse(http.StatusOK), nil
+}
+
+// fakeRepositoryFiles keep the created files in memory keyed by project path and file name
+type fakeRepositoryFiles struct {
+	repositoryFilesService
+	mu    sync.Mutex
+	files map[string]string
+}
+
+func (f *fakeRepositoryFiles) CreateFile(pid interface{}, fileName string, opt *gitlab.CreateFileOptions, options ...gitlab.RequestOptionFunc) (*gitlab.FileInfo, *gitlab.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := pid.(string) + ":" + fileName
+	if _, ok := f.files[key]; ok {
+		resp := fakeResponse(http.StatusBadRequest)
+		return nil, resp, &gitlab.ErrorResponse{Response: resp.Response, Message: "A file with this name already exists"}
+	}
+	f.files[key] = *opt.Content
+	return &gitlab.FileInfo{FilePath: fileName, Branch: *opt.Branch}, fakeResponse(http.StatusCreated), nil
+}
+
+func fakeResponse(status int) *gitlab.Response {
+	return &gitlab.Response{Response: &http.Response{StatusCode: status, Header: http.Header{}}}
+}
+
+// newFakeServer return a server of group 1 backed by the fakes instead of a GitLab instance
+func newFakeServer(projectName string, groups *fakeGroups, files *fakeRepositoryFiles) *gitlabServer {
+	groupId := 1
+	return &gitlabServer{
+		GroupId:     &groupId,
+		GroupName:   "group",
+		ProjectName: projectName,
+		api:         &gitlabServices{Groups: groups, RepositoryFiles: files},
+		projectIds:  newProjectIdCache(),
+	}
+}
+
+func testProjects() []*gitlab.Project {
+	return []*gitlab.Project{
+		{ID: 3, Name: "other", Path: "other", PathWithNamespace: "group/other"},
+		{ID: 7, Name: "My Service", Path: "my-service", PathWithNamespace: "group/my-service"},
+	}
+}
+
+func TestGetProjectId(t *testing.T) {
+	groups := &fakeGroups{projects: testProjects()}
+	server := newFakeServer("My Service", groups, nil)
+
+	for i := 0; i < 2; i++ {
+		id, err := server.GetProjectId()
+		if err != nil {
+			t.Fatalf("GetProjectId: %v", err)
+		}
+		if id != 7 {
+			t.Errorf("GetProjectId = %v, want 7", id)
+		}
+	}
+	if groups.lists != 1 {
+		t.Errorf("listed group projects %d times, want 1 with the id cached", groups.lists)
+	}
+
+	byPath := server.withProject("my-service")
+	byPath.ProjectMatch = MatchPath
+	if id, err := byPath.GetProjectId(); err != nil || id != 7 {
+		t.Errorf("GetProjectId by path = %v, %v, want 7", id, err)
+	}
+	if groups.lists != 2 {
+		t.Errorf("lookup by path was served from the lookup by name cache")
+	}
+
+	if _, err := server.withProject("missing").GetProjectId(); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("GetProjectId of a missing project = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func TestCreateFileIfAbsent(t *testing.T) {
+	files := &fakeRepositoryFiles{files: make(map[string]string)}
+	server := newFakeServer("My Service", &fakeGroups{projects: testProjects()}, files)
+
+	created, err := server.CreateFileIfAbsent("main", "app.yaml", "v1", "add app")
+	if err != nil || !created {
+		t.Fatalf("CreateFileIfAbsent = %v, %v, want true", created, err)
+	}
+	created, err = server.CreateFileIfAbsent("main", "app.yaml", "v2", "add app")
+	if err != nil || created {
+		t.Fatalf("second CreateFileIfAbsent = %v, %v, want false", created, err)
+	}
+	if content := files.files["group/my-service:app.yaml"]; content != "v1" {
+		t.Errorf("file content = %q, want %q", content, "v1")
+	}
+}
+
+func TestListGroupHooksErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups *fakeGroups
+		want   error
+	}{
+		{"edition without group hooks", &fakeGroups{hooksStatus: http.StatusNotFound}, ErrPremiumRequired},
+		{"missing group", &fakeGroups{hooksStatus: http.StatusNotFound, missing: true}, ErrGroupNotFound},
+		{"insufficient access", &fakeGroups{hooksStatus: http.StatusForbidden}, ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newFakeServer("My Service", tt.groups, nil).ListGroupHooks()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ListGroupHooks = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	server := newFakeServer("My Service", &fakeGroups{}, nil)
+	server.GroupId = nil
+	hooks, err := server.ListGroupHooks()
+	if err != nil || len(hooks) != 1 {
+		t.Errorf("ListGroupHooks by group name = %v, %v, want one hook", hooks, err)
+	}
+}
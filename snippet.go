@@ -0,0 +1,92 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListProjectSnippets list the snippets of the project
+func (git *gitlabServer) ListProjectSnippets() ([]map[string]interface{}, error) {
+	return git.ListProjectSnippetsWithContext(context.Background())
+}
+
+// ListProjectSnippetsWithContext is like ListProjectSnippets but binds the request to ctx
+func (git *gitlabServer) ListProjectSnippetsWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := gitlab.ListProjectSnippetsOptions(git.listOptions())
+	var snippetSlice []*gitlab.Snippet
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list project snippets: exceeded max pages %d", git.maxPages())
+		}
+		var snippets []*gitlab.Snippet
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			snippets, resp, err = git.api.ProjectSnippets.ListSnippets(int(projectId), &options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		snippetSlice = append(snippetSlice, snippets...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(snippetSlice)
+}
+
+// CreateProjectSnippet create a snippet of the project and return its id, visibility is
+// private, internal or public
+func (git *gitlabServer) CreateProjectSnippet(title, filename, content, visibility string) (int, error) {
+	return git.CreateProjectSnippetWithContext(context.Background(), title, filename, content, visibility)
+}
+
+// CreateProjectSnippetWithContext is like CreateProjectSnippet but binds the request to ctx
+func (git *gitlabServer) CreateProjectSnippetWithContext(ctx context.Context, title, filename, content, visibility string) (int, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	options := &gitlab.CreateProjectSnippetOptions{
+		Title:      &title,
+		FileName:   &filename,
+		Content:    &content,
+		Visibility: gitlab.Visibility(gitlab.VisibilityValue(visibility)),
+	}
+	var snippet *gitlab.Snippet
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		snippet, resp, err = git.api.ProjectSnippets.CreateSnippet(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return snippet.ID, nil
+}
+
+// DeleteProjectSnippet delete a snippet of the project
+func (git *gitlabServer) DeleteProjectSnippet(snippetId int) (string, error) {
+	return git.DeleteProjectSnippetWithContext(context.Background(), snippetId)
+}
+
+// DeleteProjectSnippetWithContext is like DeleteProjectSnippet but binds the request to ctx
+func (git *gitlabServer) DeleteProjectSnippetWithContext(ctx context.Context, snippetId int) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.ProjectSnippets.DeleteSnippet(int(projectId), snippetId, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("delete project snippet: <%d> error", snippetId), err
+	}
+	return fmt.Sprintf("delete project snippet: <%d> ok", snippetId), nil
+}
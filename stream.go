@@ -0,0 +1,86 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/xanzy/go-gitlab"
+)
+
+// streamWriter forward the response body into a pipe and signal once it starts flowing
+type streamWriter struct {
+	pw    *io.PipeWriter
+	once  sync.Once
+	ready chan struct{}
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.ready) })
+	return w.pw.Write(p)
+}
+
+// GetRawFileStream get a file content as a stream without buffering it in memory,
+// the caller is responsible for closing the returned reader
+func (git *gitlabServer) GetRawFileStream(branch, filename string) (io.ReadCloser, error) {
+	return git.GetRawFileStreamWithContext(context.Background(), branch, filename)
+}
+
+// GetRawFileStreamWithContext is like GetRawFileStream but binds the request to ctx
+func (git *gitlabServer) GetRawFileStreamWithContext(ctx context.Context, branch, filename string) (io.ReadCloser, error) {
+	if err := git.requireClient(); err != nil {
+		return nil, err
+	}
+	if err := requireArgs("get file stream", "branch", branch, "filename", filename); err != nil {
+		return nil, err
+	}
+	projectPath, err := git.getProjectPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf(
+		"projects/%s/repository/files/%s/raw",
+		gitlab.PathEscape(projectPath),
+		gitlab.PathEscape(filename),
+	)
+	gf := &gitlab.GetRawFileOptions{
+		Ref: gitlab.String(branch),
+	}
+	req, err := git.api.NewRequest(http.MethodGet, u, gf, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	return git.openStream(req, fmt.Errorf("file %s on ref %s: %w", filename, branch, ErrFileNotFound))
+}
+
+// openStream send req and stream the response body, notFound is returned in place of a 404
+func (git *gitlabServer) openStream(req *retryablehttp.Request, notFound error) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	w := &streamWriter{pw: pw, ready: make(chan struct{})}
+	done := make(chan error, 1)
+	go func() {
+		resp, err := git.api.Do(req, w)
+		if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+			err = notFound
+		}
+		git.recordRateLimit(resp)
+		pw.CloseWithError(err)
+		done <- err
+	}()
+
+	// wait for the body to start flowing so request errors are returned here, not on Read
+	select {
+	case <-w.ready:
+		return pr, nil
+	case err := <-done:
+		if err != nil {
+			pr.Close()
+			return nil, err
+		}
+		return pr, nil
+	}
+}
@@ -0,0 +1,217 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListTags list all tags of the project
+func (git *gitlabServer) ListTags() ([]map[string]interface{}, error) {
+	return git.ListTagsWithContext(context.Background())
+}
+
+// ListTagsWithContext is like ListTags but binds the request to ctx
+func (git *gitlabServer) ListTagsWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := &gitlab.ListTagsOptions{
+		ListOptions: git.listOptions(),
+	}
+	var tagSlice []*gitlab.Tag
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list tags: exceeded max pages %d", git.maxPages())
+		}
+		var tags []*gitlab.Tag
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			tags, resp, err = git.api.Tags.ListTags(int(projectId), options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		tagSlice = append(tagSlice, tags...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(tagSlice)
+}
+
+// GetTag get a single tag with its commit and message
+func (git *gitlabServer) GetTag(tagName string) (map[string]interface{}, error) {
+	return git.GetTagWithContext(context.Background(), tagName)
+}
+
+// GetTagWithContext is like GetTag but binds the request to ctx
+func (git *gitlabServer) GetTagWithContext(ctx context.Context, tagName string) (map[string]interface{}, error) {
+	if err := requireArgs("get tag", "tagName", tagName); err != nil {
+		return nil, err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var tag *gitlab.Tag
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		tag, resp, err = git.api.Tags.GetTag(int(projectId), tagName, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("tag %s: %w", tagName, ErrTagNotFound)
+		}
+		return nil, err
+	}
+	return convertToMap(tag)
+}
+
+// DeleteTag delete a tag
+func (git *gitlabServer) DeleteTag(tagName string) (string, error) {
+	return git.DeleteTagWithContext(context.Background(), tagName)
+}
+
+// DeleteTagWithContext is like DeleteTag but binds the request to ctx
+func (git *gitlabServer) DeleteTagWithContext(ctx context.Context, tagName string) (string, error) {
+	if err := requireArgs("delete tag", "tagName", tagName); err != nil {
+		return fmt.Sprintf("delete tag: <%s> error", tagName), err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.Tags.DeleteTag(int(projectId), tagName, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Sprintf("delete tag: <%s> error", tagName), fmt.Errorf("tag %s: %w", tagName, ErrTagNotFound)
+		}
+		return fmt.Sprintf("delete tag: <%s> error", tagName), err
+	}
+	return fmt.Sprintf("delete tag: <%s> ok", tagName), nil
+}
+
+// CreateTagIfAbsent create the tag on ref unless it already exists, an existing tag pointing at
+// the same commit as ref is not an error, one pointing elsewhere returns ErrTagExists
+func (git *gitlabServer) CreateTagIfAbsent(ref, tagName, message string) (bool, error) {
+	return git.CreateTagIfAbsentWithContext(context.Background(), ref, tagName, message)
+}
+
+// CreateTagIfAbsentWithContext is like CreateTagIfAbsent but binds the request to ctx
+func (git *gitlabServer) CreateTagIfAbsentWithContext(ctx context.Context, ref, tagName, message string) (bool, error) {
+	if err := requireArgs("create tag", "ref", ref, "tagName", tagName); err != nil {
+		return false, err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	var tag *gitlab.Tag
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		tag, resp, err = git.api.Tags.GetTag(int(projectId), tagName, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			if err := git.CreateTagWithContext(ctx, ref, tagName, message); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+		return false, err
+	}
+	var commit *gitlab.Commit
+	resp, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		commit, resp, err = git.api.Commits.GetCommit(int(projectId), ref, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, fmt.Errorf("ref %s: %w", ref, ErrCommitNotFound)
+		}
+		return false, err
+	}
+	// annotated tags target the tag object, the tagged commit is what matters
+	target := tag.Target
+	if tag.Commit != nil {
+		target = tag.Commit.ID
+	}
+	if target != commit.ID {
+		return false, fmt.Errorf("tag %s points at %s, not %s: %w", tagName, target, commit.ID, ErrTagExists)
+	}
+	git.logger().Debugf("tag %s already points at %s, skip create", tagName, commit.ID)
+	return false, nil
+}
+
+// ListProtectedTags list the protected tag patterns of the project
+func (git *gitlabServer) ListProtectedTags() ([]map[string]interface{}, error) {
+	return git.ListProtectedTagsWithContext(context.Background())
+}
+
+// ListProtectedTagsWithContext is like ListProtectedTags but binds the request to ctx
+func (git *gitlabServer) ListProtectedTagsWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := gitlab.ListProtectedTagsOptions(git.listOptions())
+	var tagSlice []*gitlab.ProtectedTag
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list protected tags: exceeded max pages %d", git.maxPages())
+		}
+		var tags []*gitlab.ProtectedTag
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			tags, resp, err = git.api.ProtectedTags.ListProtectedTags(int(projectId), &options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		tagSlice = append(tagSlice, tags...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(tagSlice)
+}
+
+// ProtectTag protect the tags matching tagPattern, e.g. v*, so only createLevel may create them
+func (git *gitlabServer) ProtectTag(tagPattern string, createLevel gitlab.AccessLevelValue) (string, error) {
+	return git.ProtectTagWithContext(context.Background(), tagPattern, createLevel)
+}
+
+// ProtectTagWithContext is like ProtectTag but binds the request to ctx
+func (git *gitlabServer) ProtectTagWithContext(ctx context.Context, tagPattern string, createLevel gitlab.AccessLevelValue) (string, error) {
+	if err := requireArgs("protect tag", "tagPattern", tagPattern); err != nil {
+		return fmt.Sprintf("protect tag: <%s> error", tagPattern), err
+	}
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.ProtectRepositoryTagsOptions{
+		Name:              &tagPattern,
+		CreateAccessLevel: gitlab.AccessLevel(createLevel),
+	}
+	resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.ProtectedTags.ProtectRepositoryTags(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		if isNameTaken(resp, err) {
+			return fmt.Sprintf("protect tag: <%s> error", tagPattern), fmt.Errorf("tag %s: %w", tagPattern, ErrTagAlreadyProtected)
+		}
+		return fmt.Sprintf("protect tag: <%s> error", tagPattern), err
+	}
+	return fmt.Sprintf("protect tag: <%s> ok", tagPattern), nil
+}
@@ -0,0 +1,25 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// requireArgs return an ErrInvalidArgument error naming the first empty argument of op,
+// args alternate between an argument name and its value
+func requireArgs(op string, args ...string) error {
+	for i := 0; i+1 < len(args); i += 2 {
+		if strings.TrimSpace(args[i+1]) == "" {
+			return fmt.Errorf("%s: %s is empty: %w", op, args[i], ErrInvalidArgument)
+		}
+	}
+	return nil
+}
+
+// requireClient return ErrClientNotInitialized when neither InitGitlabServer nor NewGitlabServer set the client
+func (git *gitlabServer) requireClient() error {
+	if git.api == nil {
+		return ErrClientNotInitialized
+	}
+	return nil
+}
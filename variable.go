@@ -0,0 +1,116 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// ListProjectVariables list all CI/CD variables of the project
+func (git *gitlabServer) ListProjectVariables() ([]map[string]interface{}, error) {
+	return git.ListProjectVariablesWithContext(context.Background())
+}
+
+// ListProjectVariablesWithContext is like ListProjectVariables but binds the request to ctx
+func (git *gitlabServer) ListProjectVariablesWithContext(ctx context.Context) ([]map[string]interface{}, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options := gitlab.ListProjectVariablesOptions(git.listOptions())
+	var variableSlice []*gitlab.ProjectVariable
+	for page := 0; ; page++ {
+		if page >= git.maxPages() {
+			return nil, fmt.Errorf("list project variables: exceeded max pages %d", git.maxPages())
+		}
+		var variables []*gitlab.ProjectVariable
+		resp, err := git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+			variables, resp, err = git.api.ProjectVariables.ListVariables(int(projectId), &options, optionFuncs...)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		variableSlice = append(variableSlice, variables...)
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return convertToMaps(variableSlice)
+}
+
+// CreateProjectVariable create a CI/CD variable of the project
+func (git *gitlabServer) CreateProjectVariable(key, value string, masked, protected bool) (string, error) {
+	return git.CreateProjectVariableWithContext(context.Background(), key, value, masked, protected)
+}
+
+// CreateProjectVariableWithContext is like CreateProjectVariable but binds the request to ctx
+func (git *gitlabServer) CreateProjectVariableWithContext(ctx context.Context, key, value string, masked, protected bool) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.CreateProjectVariableOptions{
+		Key:       &key,
+		Value:     &value,
+		Masked:    &masked,
+		Protected: &protected,
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.ProjectVariables.CreateVariable(int(projectId), options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("create project variable: <%s> error", key), err
+	}
+	return fmt.Sprintf("create project variable: <%s> ok", key), nil
+}
+
+// UpdateProjectVariable update a CI/CD variable of the project
+func (git *gitlabServer) UpdateProjectVariable(key, value string, masked, protected bool) (string, error) {
+	return git.UpdateProjectVariableWithContext(context.Background(), key, value, masked, protected)
+}
+
+// UpdateProjectVariableWithContext is like UpdateProjectVariable but binds the request to ctx
+func (git *gitlabServer) UpdateProjectVariableWithContext(ctx context.Context, key, value string, masked, protected bool) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	options := &gitlab.UpdateProjectVariableOptions{
+		Value:     &value,
+		Masked:    &masked,
+		Protected: &protected,
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		_, resp, err = git.api.ProjectVariables.UpdateVariable(int(projectId), key, options, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("update project variable: <%s> error", key), err
+	}
+	return fmt.Sprintf("update project variable: <%s> ok", key), nil
+}
+
+// DeleteProjectVariable delete a CI/CD variable of the project
+func (git *gitlabServer) DeleteProjectVariable(key string) (string, error) {
+	return git.DeleteProjectVariableWithContext(context.Background(), key)
+}
+
+// DeleteProjectVariableWithContext is like DeleteProjectVariable but binds the request to ctx
+func (git *gitlabServer) DeleteProjectVariableWithContext(ctx context.Context, key string) (string, error) {
+	projectId, err := git.GetProjectIdWithContext(ctx)
+	if err != nil {
+		return "get project id error", err
+	}
+	_, err = git.do(ctx, func(optionFuncs ...gitlab.RequestOptionFunc) (resp *gitlab.Response, err error) {
+		resp, err = git.api.ProjectVariables.RemoveVariable(int(projectId), key, &gitlab.RemoveProjectVariableOptions{}, optionFuncs...)
+		return
+	})
+	if err != nil {
+		return fmt.Sprintf("delete project variable: <%s> error", key), err
+	}
+	return fmt.Sprintf("delete project variable: <%s> ok", key), nil
+}